@@ -0,0 +1,283 @@
+// Command bindgen generates the Go bindings of the contracts compiled by
+// `forge build`, one file per contract declared under the source directory.
+// With -check it writes nothing and fails if the bindings on disk are stale.
+//
+// Paths are relative to the Foundry project root.
+//
+// Usage:
+//
+//	go run ./cmd/bindgen [-root .] [-out contracts/out] [-src contracts/src] [-dir contracts/bindings] [-pkg bindings] [-check]
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/abigen"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+const (
+	// GeneratedHeader starts every file abigen generates. Files in the
+	// bindings directory without it are never touched.
+	GeneratedHeader = "// Code generated - DO NOT EDIT."
+
+	ArtifactExt = ".json"
+	BindingExt  = ".go"
+
+	BindingDirMode  = 0o750
+	BindingFileMode = 0o600
+)
+
+var (
+	ErrBindgen = errors.New("bindgen")
+	ErrStale   = fmt.Errorf("%w: bindings are stale, run `go generate ./contracts/bindings`", ErrBindgen)
+)
+
+// Config holds the command-line flags of bindgen.
+type Config struct {
+	Root  string
+	Out   string
+	Src   string
+	Dir   string
+	Pkg   string
+	Check bool
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Root:  ".",
+		Out:   "contracts/out",
+		Src:   "contracts/src",
+		Dir:   "contracts/bindings",
+		Pkg:   "bindings",
+		Check: false,
+	}
+}
+
+// Binding is the generated source of one contract.
+type Binding struct {
+	Contract string
+	File     string
+	Source   []byte
+}
+
+func main() {
+	config := DefaultConfig()
+	flag.StringVar(&config.Root, "root", config.Root, "Foundry project root")
+	flag.StringVar(&config.Out, "out", config.Out, "forge build output directory")
+	flag.StringVar(&config.Src, "src", config.Src, "directory of the contracts to bind")
+	flag.StringVar(&config.Dir, "dir", config.Dir, "directory of the generated bindings")
+	flag.StringVar(&config.Pkg, "pkg", config.Pkg, "package name of the generated bindings")
+	flag.BoolVar(&config.Check, "check", config.Check, "fail if the bindings are stale instead of writing them")
+	flag.Parse()
+
+	err := Run(config, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Run generates the bindings and either writes them, removing the bindings of
+// contracts that no longer exist, or checks them against the bindings on disk.
+func Run(config *Config, w io.Writer) error {
+	bindings, err := Generate(config)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(config.Root, config.Dir)
+	orphans, err := orphanedBindings(dir, bindings)
+	if err != nil {
+		return err
+	}
+
+	if config.Check {
+		return check(dir, bindings, orphans, w)
+	}
+	return write(dir, bindings, orphans, w)
+}
+
+// Generate binds every contract whose compilation target is under the source
+// directory, in contract name order.
+func Generate(config *Config) ([]*Binding, error) {
+	outDir := filepath.Join(config.Root, config.Out)
+	paths, err := filepath.Glob(filepath.Join(outDir, "*", "*"+ArtifactExt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: searching artifacts: %w", ErrBindgen, err)
+	}
+
+	src := filepath.ToSlash(filepath.Clean(config.Src)) + "/"
+	bindings := []*Binding{}
+	for _, path := range paths {
+		binding, err := generateBinding(path, src, config.Pkg)
+		if err != nil {
+			return nil, err
+		}
+		if binding != nil {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	slices.SortFunc(bindings, func(a, b *Binding) int { return strings.Compare(a.Contract, b.Contract) })
+	return bindings, nil
+}
+
+// generateBinding binds the artifact at path, or returns nil if the contract
+// is not declared under src.
+//
+//nolint:nilnil
+func generateBinding(path string, src string, pkg string) (*Binding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %w", ErrBindgen, path, err)
+	}
+
+	artifact := &foundry.Artifact{}
+	err = json.Unmarshal(data, artifact)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrBindgen, path, err)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), ArtifactExt)
+	if !declaredUnder(artifact, name, src) {
+		return nil, nil
+	}
+	if artifact.NeedsLinking() {
+		return nil, fmt.Errorf("%w: %s: linking libraries is not supported", ErrBindgen, name)
+	}
+
+	// abigen embeds the ABI as written in the artifact, so keep it raw.
+	raw := struct {
+		ABI json.RawMessage `json:"abi"`
+	}{}
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrBindgen, path, err)
+	}
+
+	bytecode := ""
+	if len(artifact.Bytecode) > 0 {
+		bytecode = hexutil.Encode(artifact.Bytecode)
+	}
+
+	source, err := abigen.Bind(
+		[]string{name},
+		[]string{string(raw.ABI)},
+		[]string{bytecode},
+		[]map[string]string{artifact.MethodIdentifiers},
+		pkg,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: binding %s: %w", ErrBindgen, name, err)
+	}
+
+	return &Binding{
+		Contract: name,
+		File:     strings.ToLower(name) + BindingExt,
+		Source:   []byte(source),
+	}, nil
+}
+
+// declaredUnder reports whether the artifact is the contract name compiled
+// from a file under src. Artifacts of dependencies and of contracts built by
+// several compiler versions (Name.0.8.33.json) are not.
+func declaredUnder(artifact *foundry.Artifact, name string, src string) bool {
+	if artifact.Metadata == nil || artifact.Metadata.Settings == nil {
+		return false
+	}
+	for file, contract := range artifact.Metadata.Settings.CompilationTarget {
+		if contract == name && strings.HasPrefix(file, src) {
+			return true
+		}
+	}
+	return false
+}
+
+// orphanedBindings returns the generated files in dir that no binding
+// produces anymore.
+func orphanedBindings(dir string, bindings []*Binding) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+BindingExt))
+	if err != nil {
+		return nil, fmt.Errorf("%w: searching bindings: %w", ErrBindgen, err)
+	}
+
+	orphans := []string{}
+	for _, path := range paths {
+		file := filepath.Base(path)
+		if slices.ContainsFunc(bindings, func(b *Binding) bool { return b.File == file }) {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading %s: %w", ErrBindgen, path, err)
+		}
+		if bytes.HasPrefix(data, []byte(GeneratedHeader)) {
+			orphans = append(orphans, path)
+		}
+	}
+	return orphans, nil
+}
+
+func check(dir string, bindings []*Binding, orphans []string, w io.Writer) error {
+	stale := []string{}
+	for _, binding := range bindings {
+		path := filepath.Join(dir, binding.File)
+		current, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			stale = append(stale, path+" (missing)")
+		case err != nil:
+			return fmt.Errorf("%w: reading %s: %w", ErrBindgen, path, err)
+		case !bytes.Equal(current, binding.Source):
+			stale = append(stale, path)
+		}
+	}
+	for _, orphan := range orphans {
+		stale = append(stale, orphan+" (no contract)")
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("%w: %s", ErrStale, strings.Join(stale, ", "))
+	}
+	fmt.Fprintf(w, "%d bindings up to date\n", len(bindings))
+	return nil
+}
+
+func write(dir string, bindings []*Binding, orphans []string, w io.Writer) error {
+	err := os.MkdirAll(dir, BindingDirMode)
+	if err != nil {
+		return fmt.Errorf("%w: creating %s: %w", ErrBindgen, dir, err)
+	}
+
+	for _, binding := range bindings {
+		path := filepath.Join(dir, binding.File)
+		err = os.WriteFile(path, binding.Source, BindingFileMode)
+		if err != nil {
+			return fmt.Errorf("%w: writing %s: %w", ErrBindgen, path, err)
+		}
+		fmt.Fprintf(w, "wrote %s\n", path)
+	}
+
+	for _, orphan := range orphans {
+		err = os.Remove(orphan)
+		if err != nil {
+			return fmt.Errorf("%w: removing %s: %w", ErrBindgen, orphan, err)
+		}
+		fmt.Fprintf(w, "removed %s\n", orphan)
+	}
+	return nil
+}
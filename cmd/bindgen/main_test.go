@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const artifactFixture = "../../test/foundry/testdata/artifact.json"
+
+func TestRun(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		config := newTestProject(t)
+		writeFile(t, config, "contracts/bindings/generate.go", "package bindings\n")
+		writeFile(t, config, "contracts/bindings/removed.go", GeneratedHeader+"\npackage bindings\n")
+		out := &bytes.Buffer{}
+
+		// when
+		err := Run(config, out)
+
+		// then
+		require.NoError(t, err)
+		source := readFile(t, config, "contracts/bindings/bearcoin.go")
+		require.Contains(t, source, "func DeployBearCoin(")
+		require.Contains(t, source, "package bindings")
+		require.NoFileExists(t, filepath.Join(config.Root, "contracts/bindings/test.go"))
+		require.NoFileExists(t, filepath.Join(config.Root, "contracts/bindings/removed.go"))
+		require.FileExists(t, filepath.Join(config.Root, "contracts/bindings/generate.go"))
+		require.Contains(t, out.String(), "removed.go")
+	})
+
+	t.Run("happy path - check up to date", func(t *testing.T) {
+		// given
+		config := newTestProject(t)
+		require.NoError(t, Run(config, &bytes.Buffer{}))
+		config.Check = true
+		out := &bytes.Buffer{}
+
+		// when
+		err := Run(config, out)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, "1 bindings up to date\n", out.String())
+	})
+
+	t.Run("error - check stale", func(t *testing.T) {
+		// given
+		config := newTestProject(t)
+		require.NoError(t, Run(config, &bytes.Buffer{}))
+		writeFile(t, config, "contracts/bindings/bearcoin.go", GeneratedHeader+"\npackage bindings\n")
+		config.Check = true
+
+		// when
+		err := Run(config, &bytes.Buffer{})
+
+		// then
+		require.ErrorIs(t, err, ErrStale)
+		require.ErrorContains(t, err, "bearcoin.go")
+	})
+
+	t.Run("error - check missing and orphaned", func(t *testing.T) {
+		// given
+		config := newTestProject(t)
+		writeFile(t, config, "contracts/bindings/removed.go", GeneratedHeader+"\npackage bindings\n")
+		config.Check = true
+
+		// when
+		err := Run(config, &bytes.Buffer{})
+
+		// then
+		require.ErrorIs(t, err, ErrStale)
+		require.ErrorContains(t, err, "bearcoin.go (missing)")
+		require.ErrorContains(t, err, "removed.go (no contract)")
+		require.FileExists(t, filepath.Join(config.Root, "contracts/bindings/removed.go"))
+	})
+
+	t.Run("error - invalid artifact", func(t *testing.T) {
+		// given
+		config := newTestProject(t)
+		writeFile(t, config, "contracts/out/Broken.sol/Broken.json", "{")
+
+		// when
+		err := Run(config, &bytes.Buffer{})
+
+		// then
+		require.ErrorIs(t, err, ErrBindgen)
+	})
+}
+
+// newTestProject lays out a Foundry project with the BearCoin artifact
+// compiled from contracts/src and a dependency compiled from contracts/libs.
+func newTestProject(t *testing.T) *Config {
+	t.Helper()
+	config := DefaultConfig()
+	config.Root = t.TempDir()
+
+	fixture, err := os.ReadFile(artifactFixture)
+	require.NoError(t, err)
+	writeFile(t, config, "contracts/out/BearCoin.sol/BearCoin.json", withTarget(t, fixture, "contracts/src/BearCoin.sol", "BearCoin"))
+	writeFile(t, config, "contracts/out/Test.sol/Test.json", withTarget(t, fixture, "contracts/libs/forge-std/src/Test.sol", "Test"))
+	return config
+}
+
+// withTarget returns the artifact with its compilation target set.
+func withTarget(t *testing.T, artifact []byte, file string, contract string) string {
+	t.Helper()
+	fields := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(artifact, &fields))
+
+	metadata, err := json.Marshal(map[string]any{
+		"settings": map[string]any{"compilationTarget": map[string]string{file: contract}},
+	})
+	require.NoError(t, err)
+	fields["metadata"] = metadata
+
+	data, err := json.Marshal(fields)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func writeFile(t *testing.T, config *Config, path string, data string) {
+	t.Helper()
+	path = filepath.Join(config.Root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+}
+
+func readFile(t *testing.T, config *Config, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(config.Root, path))
+	require.NoError(t, err)
+	return string(data)
+}
@@ -32,12 +32,55 @@ var (
 // BearCoinMetaData contains all meta data concerning the BearCoin contract.
 var BearCoinMetaData = &bind.MetaData{
 	ABI: "[{\"type\":\"constructor\",\"inputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"DECIMALS\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"TOTAL_SUPPLY\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"allowance\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"approve\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"balanceOf\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"burn\",\"inputs\":[{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"decimals\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"stateMutability\":\"pure\"},{\"type\":\"function\",\"name\":\"mint\",\"inputs\":[{\"name\":\"recipient\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"name\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"symbol\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"string\",\"internalType\":\"string\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"totalSupply\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"transfer\",\"inputs\":[{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferFrom\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"Approval\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"spender\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Burn\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Mint\",\"inputs\":[{\"name\":\"to\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Transfer\",\"inputs\":[{\"name\":\"from\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"to\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"value\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"ERC20InsufficientAllowance\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"allowance\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"needed\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"ERC20InsufficientBalance\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"balance\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"needed\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"ERC20InvalidApprover\",\"inputs\":[{\"name\":\"approver\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"ERC20InvalidReceiver\",\"inputs\":[{\"name\":\"receiver\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"ERC20InvalidSender\",\"inputs\":[{\"name\":\"sender\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"ERC20InvalidSpender\",\"inputs\":[{\"name\":\"spender\",\"type\":\"address\",\"internalType\":\"address\"}]}]",
+	Sigs: map[string]string{
+		"2e0f2625": "DECIMALS()",
+		"902d55a5": "TOTAL_SUPPLY()",
+		"dd62ed3e": "allowance(address,address)",
+		"095ea7b3": "approve(address,uint256)",
+		"70a08231": "balanceOf(address)",
+		"42966c68": "burn(uint256)",
+		"313ce567": "decimals()",
+		"40c10f19": "mint(address,uint256)",
+		"06fdde03": "name()",
+		"8da5cb5b": "owner()",
+		"95d89b41": "symbol()",
+		"18160ddd": "totalSupply()",
+		"a9059cbb": "transfer(address,uint256)",
+		"23b872dd": "transferFrom(address,address,uint256)",
+		"f2fde38b": "transferOwnership(address)",
+	},
+	Bin: "0x608060405234801561000f575f5ffd5b506040518060400160405280600881526020017f42656172436f696e0000000000000000000000000000000000000000000000008152506040518060400160405280600381526020017f42434e0000000000000000000000000000000000000000000000000000000000815250816003908161008b91906105fd565b50806004908161009b91906105fd565b5050503360055f6101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555061010c33601260ff16600a6100f39190610828565b620f42406101019190610872565b61011160201b60201c565b61099b565b5f73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1603610181575f6040517fec442f0500000000000000000000000000000000000000000000000000000000815260040161017891906108f2565b60405180910390fd5b6101925f838361019660201b60201c565b5050565b5f73ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff16036101e6578060025f8282546101da919061090b565b925050819055506102b4565b5f5f5f8573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f205490508181101561026f578381836040517fe450d38c0000000000000000000000000000000000000000000000000000000081526004016102669392919061094d565b60405180910390fd5b8181035f5f8673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f2081905550505b5f73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff16036102fb578060025f8282540392505081905550610345565b805f5f8473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f205f82825401925050819055505b8173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040516103a29190610982565b60405180910390a3505050565b5f81519050919050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52604160045260245ffd5b7f4e487b71000000000000000000000000000000000000000000000000000000005f52602260045260245ffd5b5f600282049050600182168061042a57607f821691505b60208210810361043d5761043c6103e6565b5b50919050565b5f819050815f5260205f209050919050565b5f6020601f8301049050919050565b5f82821b905092915050565b5f6008830261049f7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82610464565b6104a98683610464565b95508019841693508086168417925050509392505050565b5f819050919050565b5f819050919050565b5f6104ed6104e86104e3846104c1565b6104ca565b6104c1565b9050919050565b5f819050919050565b610506836104d3565b61051a610512826104f4565b848454610470565b825550505050565b5f5f905090565b610531610522565b61053c8184846104fd565b505050565b5f5b82811015610562576105575f828401610529565b600181019050610543565b505050565b601f8211156105b557828211156105b45761058181610443565b61058a83610455565b61059385610455565b60208610156105a0575f90505b8083016105af82840382610541565b505050505b5b505050565b5f82821c905092915050565b5f6105d55f19846008026105ba565b1980831691505092915050565b5f6105ed83836105c6565b9150826002028217905092915050565b610606826103af565b67ffffffffffffffff81111561061f5761061e6103b9565b5b6106298254610413565b610634828285610567565b5f60209050601f831160018114610665575f8415610653578287015190505b61065d85826105e2565b8655506106c4565b601f19841661067386610443565b5f5b8281101561069a57848901518255600182019150602085019450602081019050610675565b868310156106b757848901516106b3601f8916826105c6565b8355505b6001600288020188555050505b505050505050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52601160045260245ffd5b5f8160011c9050919050565b5f5f8291508390505b600185111561074e5780860481111561072a576107296106cc565b5b60018516156107395780820291505b8081029050610747856106f9565b945061070e565b94509492505050565b5f826107665760019050610821565b81610773575f9050610821565b81600181146107895760028114610793576107c2565b6001915050610821565b60ff8411156107a5576107a46106cc565b5b8360020a9150848211156107bc576107bb6106cc565b5b50610821565b5060208310610133831016604e8410600b84101617156107f75782820a9050838111156107f2576107f16106cc565b5b610821565b6108048484846001610705565b9250905081840481111561081b5761081a6106cc565b5b81810290505b9392505050565b5f610832826104c1565b915061083d836104c1565b925061086a7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8484610757565b905092915050565b5f61087c826104c1565b9150610887836104c1565b9250828202610895816104c1565b915082820484148315176108ac576108ab6106cc565b5b5092915050565b5f73ffffffffffffffffffffffffffffffffffffffff82169050919050565b5f6108dc826108b3565b9050919050565b6108ec816108d2565b82525050565b5f6020820190506109055f8301846108e3565b92915050565b5f610915826104c1565b9150610920836104c1565b9250828201905080821115610938576109376106cc565b5b92915050565b610947816104c1565b82525050565b5f6060820190506109605f8301866108e3565b61096d602083018561093e565b61097a604083018461093e565b949350505050565b5f6020820190506109955f83018461093e565b92915050565b6115d8806109a85f395ff3fe608060405234801561000f575f5ffd5b50600436106100f3575f3560e01c806342966c681161009557806395d89b411161006457806395d89b4114610273578063a9059cbb14610291578063dd62ed3e146102c1578063f2fde38b146102f1576100f3565b806342966c68146101eb57806370a08231146102075780638da5cb5b14610237578063902d55a514610255576100f3565b806323b872dd116100d157806323b872dd146101635780632e0f262514610193578063313ce567146101b157806340c10f19146101cf576100f3565b806306fdde03146100f7578063095ea7b31461011557806318160ddd14610145575b5f5ffd5b6100ff61030d565b60405161010c9190610f34565b60405180910390f35b61012f600480360381019061012a9190610fe5565b61039d565b60405161013c919061103d565b60405180910390f35b61014d6103bf565b60405161015a9190611065565b60405180910390f35b61017d6004803603810190610178919061107e565b6103c8565b60405161018a919061103d565b60405180910390f35b61019b6103f6565b6040516101a891906110e9565b60405180910390f35b6101b96103fb565b6040516101c691906110e9565b60405180910390f35b6101e960048036038101906101e49190610fe5565b610403565b005b61020560048036038101906102009190611102565b6104db565b005b610221600480360381019061021c919061112d565b610536565b60405161022e9190611065565b60405180910390f35b61023f61057b565b60405161024c9190611167565b60405180910390f35b61025d6105a0565b60405161026a9190611065565b60405180910390f35b61027b6105c2565b6040516102889190610f34565b60405180910390f35b6102ab60048036038101906102a69190610fe5565b610652565b6040516102b8919061103d565b60405180910390f35b6102db60048036038101906102d69190611180565b610674565b6040516102e89190611065565b60405180910390f35b61030b6004803603810190610306919061112d565b6106f6565b005b60606003805461031c906111eb565b80601f0160208091040260200160405190810160405280929190818152602001828054610348906111eb565b80156103935780601f1061036a57610100808354040283529160200191610393565b820191905f5260205f20905b81548152906001019060200180831161037657829003601f168201915b5050505050905090565b5f5f6103a76107b0565b90506103b48185856107b7565b600191505092915050565b5f600254905090565b5f5f6103d26107b0565b90506103df8582856107c9565b6103ea85858561085c565b60019150509392505050565b601281565b5f6012905090565b61040c3361094c565b601260ff16600a61041d9190611377565b620f424061042b91906113c1565b816104346103bf565b61043e9190611402565b111561047f576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016104769061147f565b60405180910390fd5b61048982826109de565b8173ffffffffffffffffffffffffffffffffffffffff167f0f6798a560793a54c3bcfe86a93cde1e73087d944c0ea20544137d4121396885826040516104cf9190611065565b60405180910390a25050565b6104e53382610a5d565b3373ffffffffffffffffffffffffffffffffffffffff167fcc16f5dbb4873280815c1ee09dbd06736cffcc184412cf7a71a0fdb75d397ca58260405161052b9190611065565b60405180910390a250565b5f5f5f8373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f20549050919050565b60055f9054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b601260ff16600a6105b19190611377565b620f42406105bf91906113c1565b81565b6060600480546105d1906111eb565b80601f01602080910402602001604051908101604052809291908181526020018280546105fd906111eb565b80156106485780601f1061061f57610100808354040283529160200191610648565b820191905f5260205f20905b81548152906001019060200180831161062b57829003601f168201915b5050505050905090565b5f5f61065c6107b0565b905061066981858561085c565b600191505092915050565b5f60015f8473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f205f8373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f2054905092915050565b6106ff3361094c565b5f73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff160361076d576040517f08c379a0000000000000000000000000000000000000000000000000000000008152600401610764906114e7565b60405180910390fd5b8060055f6101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b5f33905090565b6107c48383836001610adc565b505050565b5f6107d48484610674565b90507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8110156108565781811015610847578281836040517ffb8f41b200000000000000000000000000000000000000000000000000000000815260040161083e93929190611505565b60405180910390fd5b61085584848484035f610adc565b5b50505050565b5f73ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff16036108cc575f6040517f96c6fd1e0000000000000000000000000000000000000000000000000000000081526004016108c39190611167565b60405180910390fd5b5f73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff160361093c575f6040517fec442f050000000000000000000000000000000000000000000000000000000081526004016109339190611167565b60405180910390fd5b610947838383610cab565b505050565b60055f9054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16146109db576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004016109d290611584565b60405180910390fd5b50565b5f73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1603610a4e575f6040517fec442f05000000000000000000000000000000000000000000000000000000008152600401610a459190611167565b60405180910390fd5b610a595f8383610cab565b5050565b5f73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1603610acd575f6040517f96c6fd1e000000000000000000000000000000000000000000000000000000008152600401610ac49190611167565b60405180910390fd5b610ad8825f83610cab565b5050565b5f73ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff1603610b4c575f6040517fe602df05000000000000000000000000000000000000000000000000000000008152600401610b439190611167565b60405180910390fd5b5f73ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff1603610bbc575f6040517f94280d62000000000000000000000000000000000000000000000000000000008152600401610bb39190611167565b60405180910390fd5b8160015f8673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f205f8573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f20819055508015610ca5578273ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92584604051610c9c9190611065565b60405180910390a35b50505050565b5f73ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff1603610cfb578060025f828254610cef9190611402565b92505081905550610dc9565b5f5f5f8573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f2054905081811015610d84578381836040517fe450d38c000000000000000000000000000000000000000000000000000000008152600401610d7b93929190611505565b60405180910390fd5b8181035f5f8673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f2081905550505b5f73ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1603610e10578060025f8282540392505081905550610e5a565b805f5f8473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020015f205f82825401925050819055505b8173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef83604051610eb79190611065565b60405180910390a3505050565b5f81519050919050565b5f82825260208201905092915050565b8281835e5f83830152505050565b5f601f19601f8301169050919050565b5f610f0682610ec4565b610f108185610ece565b9350610f20818560208601610ede565b610f2981610eec565b840191505092915050565b5f6020820190508181035f830152610f4c8184610efc565b905092915050565b5f5ffd5b5f73ffffffffffffffffffffffffffffffffffffffff82169050919050565b5f610f8182610f58565b9050919050565b610f9181610f77565b8114610f9b575f5ffd5b50565b5f81359050610fac81610f88565b92915050565b5f819050919050565b610fc481610fb2565b8114610fce575f5ffd5b50565b5f81359050610fdf81610fbb565b92915050565b5f5f60408385031215610ffb57610ffa610f54565b5b5f61100885828601610f9e565b925050602061101985828601610fd1565b9150509250929050565b5f8115159050919050565b61103781611023565b82525050565b5f6020820190506110505f83018461102e565b92915050565b61105f81610fb2565b82525050565b5f6020820190506110785f830184611056565b92915050565b5f5f5f6060848603121561109557611094610f54565b5b5f6110a286828701610f9e565b93505060206110b386828701610f9e565b92505060406110c486828701610fd1565b9150509250925092565b5f60ff82169050919050565b6110e3816110ce565b82525050565b5f6020820190506110fc5f8301846110da565b92915050565b5f6020828403121561111757611116610f54565b5b5f61112484828501610fd1565b91505092915050565b5f6020828403121561114257611141610f54565b5b5f61114f84828501610f9e565b91505092915050565b61116181610f77565b82525050565b5f60208201905061117a5f830184611158565b92915050565b5f5f6040838503121561119657611195610f54565b5b5f6111a385828601610f9e565b92505060206111b485828601610f9e565b9150509250929050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52602260045260245ffd5b5f600282049050600182168061120257607f821691505b602082108103611215576112146111be565b5b50919050565b7f4e487b71000000000000000000000000000000000000000000000000000000005f52601160045260245ffd5b5f8160011c9050919050565b5f5f8291508390505b600185111561129d578086048111156112795761127861121b565b5b60018516156112885780820291505b808102905061129685611248565b945061125d565b94509492505050565b5f826112b55760019050611370565b816112c2575f9050611370565b81600181146112d857600281146112e257611311565b6001915050611370565b60ff8411156112f4576112f361121b565b5b8360020a91508482111561130b5761130a61121b565b5b50611370565b5060208310610133831016604e8410600b84101617156113465782820a9050838111156113415761134061121b565b5b611370565b6113538484846001611254565b9250905081840481111561136a5761136961121b565b5b81810290505b9392505050565b5f61138182610fb2565b915061138c83610fb2565b92506113b97fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff84846112a6565b905092915050565b5f6113cb82610fb2565b91506113d683610fb2565b92508282026113e481610fb2565b915082820484148315176113fb576113fa61121b565b5b5092915050565b5f61140c82610fb2565b915061141783610fb2565b925082820190508082111561142f5761142e61121b565b5b92915050565b7f4d696e74696e67206578636565647320746f74616c20737570706c79000000005f82015250565b5f611469601c83610ece565b915061147482611435565b602082019050919050565b5f6020820190508181035f8301526114968161145d565b9050919050565b7f4e6577206f776e65722063616e6e6f74206265206e756c6c00000000000000005f82015250565b5f6114d1601883610ece565b91506114dc8261149d565b602082019050919050565b5f6020820190508181035f8301526114fe816114c5565b9050919050565b5f6060820190506115185f830186611158565b6115256020830185611056565b6115326040830184611056565b949350505050565b7f4e6f74206f776e657200000000000000000000000000000000000000000000005f82015250565b5f61156e600983610ece565b91506115798261153a565b602082019050919050565b5f6020820190508181035f83015261159b81611562565b905091905056fea264697066735822122023f6c7b21ba3ef5c5b9885a37d69ff91c8dff78f7d4ff02e1c424ffa543aa42464736f6c63430008210033",
 }
 
 // BearCoinABI is the input ABI used to generate the binding from.
 // Deprecated: Use BearCoinMetaData.ABI instead.
 var BearCoinABI = BearCoinMetaData.ABI
 
+// Deprecated: Use BearCoinMetaData.Sigs instead.
+// BearCoinFuncSigs maps the 4-byte function signature to its string representation.
+var BearCoinFuncSigs = BearCoinMetaData.Sigs
+
+// BearCoinBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use BearCoinMetaData.Bin instead.
+var BearCoinBin = BearCoinMetaData.Bin
+
+// DeployBearCoin deploys a new Ethereum contract, binding an instance of BearCoin to it.
+func DeployBearCoin(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *BearCoin, error) {
+	parsed, err := BearCoinMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(BearCoinBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &BearCoin{BearCoinCaller: BearCoinCaller{contract: contract}, BearCoinTransactor: BearCoinTransactor{contract: contract}, BearCoinFilterer: BearCoinFilterer{contract: contract}}, nil
+}
+
 // BearCoin is an auto generated Go binding around an Ethereum contract.
 type BearCoin struct {
 	BearCoinCaller     // Read-only binding to the contract
@@ -0,0 +1,5 @@
+// Package bindings holds the Go bindings of the contracts in contracts/src.
+// Regenerate them after `forge build` with `go generate ./contracts/bindings`.
+package bindings
+
+//go:generate go run ../../cmd/bindgen -root ../..
@@ -0,0 +1,26 @@
+pragma solidity ^0.8.33;
+
+import {BearCoin} from "../src/BearCoin.sol";
+import {Script} from "forge-std/Script.sol";
+
+// Deploys BearCoin and spreads its supply evenly across Anvil's ten default
+// accounts. Run against `anvil --dump-state` to regenerate the integration
+// test state fixture (see `make state-bear-coin`).
+contract BearCoinStateScript is Script {
+    string public constant MNEMONIC = "test test test test test test test test test test test junk";
+    uint32 public constant NUM_ACCOUNTS = 10;
+
+    BearCoin public bcn;
+
+    function setUp() public {}
+
+    function run() public {
+        vm.startBroadcast();
+        bcn = new BearCoin();
+        uint256 share = bcn.TOTAL_SUPPLY() / NUM_ACCOUNTS;
+        for (uint32 i = 1; i < NUM_ACCOUNTS; i++) {
+            require(bcn.transfer(vm.addr(vm.deriveKey(MNEMONIC, i)), share), "Transfer failed");
+        }
+        vm.stopBroadcast();
+    }
+}
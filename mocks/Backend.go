@@ -0,0 +1,712 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewBackend creates a new instance of Backend. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBackend(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Backend {
+	mock := &Backend{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// Backend is an autogenerated mock type for the Backend type
+type Backend struct {
+	mock.Mock
+}
+
+type Backend_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Backend) EXPECT() *Backend_Expecter {
+	return &Backend_Expecter{mock: &_m.Mock}
+}
+
+// CallContract provides a mock function for the type Backend
+func (_mock *Backend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	ret := _mock.Called(ctx, call, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CallContract")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error)); ok {
+		return returnFunc(ctx, call, blockNumber)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg, *big.Int) []byte); ok {
+		r0 = returnFunc(ctx, call, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg, *big.Int) error); ok {
+		r1 = returnFunc(ctx, call, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_CallContract_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CallContract'
+type Backend_CallContract_Call struct {
+	*mock.Call
+}
+
+// CallContract is a helper method to define mock.On call
+//   - ctx
+//   - call
+//   - blockNumber
+func (_e *Backend_Expecter) CallContract(ctx interface{}, call interface{}, blockNumber interface{}) *Backend_CallContract_Call {
+	return &Backend_CallContract_Call{Call: _e.mock.On("CallContract", ctx, call, blockNumber)}
+}
+
+func (_c *Backend_CallContract_Call) Run(run func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int)) *Backend_CallContract_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ethereum.CallMsg), args[2].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Backend_CallContract_Call) Return(bytes []byte, err error) *Backend_CallContract_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *Backend_CallContract_Call) RunAndReturn(run func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)) *Backend_CallContract_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CodeAt provides a mock function for the type Backend
+func (_mock *Backend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	ret := _mock.Called(ctx, contract, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CodeAt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) ([]byte, error)); ok {
+		return returnFunc(ctx, contract, blockNumber)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) []byte); ok {
+		r0 = returnFunc(ctx, contract, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, *big.Int) error); ok {
+		r1 = returnFunc(ctx, contract, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_CodeAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CodeAt'
+type Backend_CodeAt_Call struct {
+	*mock.Call
+}
+
+// CodeAt is a helper method to define mock.On call
+//   - ctx
+//   - contract
+//   - blockNumber
+func (_e *Backend_Expecter) CodeAt(ctx interface{}, contract interface{}, blockNumber interface{}) *Backend_CodeAt_Call {
+	return &Backend_CodeAt_Call{Call: _e.mock.On("CodeAt", ctx, contract, blockNumber)}
+}
+
+func (_c *Backend_CodeAt_Call) Run(run func(ctx context.Context, contract common.Address, blockNumber *big.Int)) *Backend_CodeAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Backend_CodeAt_Call) Return(bytes []byte, err error) *Backend_CodeAt_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *Backend_CodeAt_Call) RunAndReturn(run func(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)) *Backend_CodeAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EstimateGas provides a mock function for the type Backend
+func (_mock *Backend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	ret := _mock.Called(ctx, call)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateGas")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg) (uint64, error)); ok {
+		return returnFunc(ctx, call)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.CallMsg) uint64); ok {
+		r0 = returnFunc(ctx, call)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ethereum.CallMsg) error); ok {
+		r1 = returnFunc(ctx, call)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_EstimateGas_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateGas'
+type Backend_EstimateGas_Call struct {
+	*mock.Call
+}
+
+// EstimateGas is a helper method to define mock.On call
+//   - ctx
+//   - call
+func (_e *Backend_Expecter) EstimateGas(ctx interface{}, call interface{}) *Backend_EstimateGas_Call {
+	return &Backend_EstimateGas_Call{Call: _e.mock.On("EstimateGas", ctx, call)}
+}
+
+func (_c *Backend_EstimateGas_Call) Run(run func(ctx context.Context, call ethereum.CallMsg)) *Backend_EstimateGas_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ethereum.CallMsg))
+	})
+	return _c
+}
+
+func (_c *Backend_EstimateGas_Call) Return(v uint64, err error) *Backend_EstimateGas_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *Backend_EstimateGas_Call) RunAndReturn(run func(ctx context.Context, call ethereum.CallMsg) (uint64, error)) *Backend_EstimateGas_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FilterLogs provides a mock function for the type Backend
+func (_mock *Backend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	ret := _mock.Called(ctx, q)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilterLogs")
+	}
+
+	var r0 []types.Log
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.FilterQuery) ([]types.Log, error)); ok {
+		return returnFunc(ctx, q)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.FilterQuery) []types.Log); ok {
+		r0 = returnFunc(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Log)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ethereum.FilterQuery) error); ok {
+		r1 = returnFunc(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_FilterLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FilterLogs'
+type Backend_FilterLogs_Call struct {
+	*mock.Call
+}
+
+// FilterLogs is a helper method to define mock.On call
+//   - ctx
+//   - q
+func (_e *Backend_Expecter) FilterLogs(ctx interface{}, q interface{}) *Backend_FilterLogs_Call {
+	return &Backend_FilterLogs_Call{Call: _e.mock.On("FilterLogs", ctx, q)}
+}
+
+func (_c *Backend_FilterLogs_Call) Run(run func(ctx context.Context, q ethereum.FilterQuery)) *Backend_FilterLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ethereum.FilterQuery))
+	})
+	return _c
+}
+
+func (_c *Backend_FilterLogs_Call) Return(logs []types.Log, err error) *Backend_FilterLogs_Call {
+	_c.Call.Return(logs, err)
+	return _c
+}
+
+func (_c *Backend_FilterLogs_Call) RunAndReturn(run func(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)) *Backend_FilterLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HeaderByNumber provides a mock function for the type Backend
+func (_mock *Backend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	ret := _mock.Called(ctx, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeaderByNumber")
+	}
+
+	var r0 *types.Header
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *big.Int) (*types.Header, error)); ok {
+		return returnFunc(ctx, number)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *big.Int) *types.Header); ok {
+		r0 = returnFunc(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = returnFunc(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_HeaderByNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeaderByNumber'
+type Backend_HeaderByNumber_Call struct {
+	*mock.Call
+}
+
+// HeaderByNumber is a helper method to define mock.On call
+//   - ctx
+//   - number
+func (_e *Backend_Expecter) HeaderByNumber(ctx interface{}, number interface{}) *Backend_HeaderByNumber_Call {
+	return &Backend_HeaderByNumber_Call{Call: _e.mock.On("HeaderByNumber", ctx, number)}
+}
+
+func (_c *Backend_HeaderByNumber_Call) Run(run func(ctx context.Context, number *big.Int)) *Backend_HeaderByNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *Backend_HeaderByNumber_Call) Return(header *types.Header, err error) *Backend_HeaderByNumber_Call {
+	_c.Call.Return(header, err)
+	return _c
+}
+
+func (_c *Backend_HeaderByNumber_Call) RunAndReturn(run func(ctx context.Context, number *big.Int) (*types.Header, error)) *Backend_HeaderByNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingCodeAt provides a mock function for the type Backend
+func (_mock *Backend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	ret := _mock.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingCodeAt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address) ([]byte, error)); ok {
+		return returnFunc(ctx, account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address) []byte); ok {
+		r0 = returnFunc(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = returnFunc(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_PendingCodeAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingCodeAt'
+type Backend_PendingCodeAt_Call struct {
+	*mock.Call
+}
+
+// PendingCodeAt is a helper method to define mock.On call
+//   - ctx
+//   - account
+func (_e *Backend_Expecter) PendingCodeAt(ctx interface{}, account interface{}) *Backend_PendingCodeAt_Call {
+	return &Backend_PendingCodeAt_Call{Call: _e.mock.On("PendingCodeAt", ctx, account)}
+}
+
+func (_c *Backend_PendingCodeAt_Call) Run(run func(ctx context.Context, account common.Address)) *Backend_PendingCodeAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *Backend_PendingCodeAt_Call) Return(bytes []byte, err error) *Backend_PendingCodeAt_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *Backend_PendingCodeAt_Call) RunAndReturn(run func(ctx context.Context, account common.Address) ([]byte, error)) *Backend_PendingCodeAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingNonceAt provides a mock function for the type Backend
+func (_mock *Backend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	ret := _mock.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingNonceAt")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address) (uint64, error)); ok {
+		return returnFunc(ctx, account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address) uint64); ok {
+		r0 = returnFunc(ctx, account)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = returnFunc(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_PendingNonceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingNonceAt'
+type Backend_PendingNonceAt_Call struct {
+	*mock.Call
+}
+
+// PendingNonceAt is a helper method to define mock.On call
+//   - ctx
+//   - account
+func (_e *Backend_Expecter) PendingNonceAt(ctx interface{}, account interface{}) *Backend_PendingNonceAt_Call {
+	return &Backend_PendingNonceAt_Call{Call: _e.mock.On("PendingNonceAt", ctx, account)}
+}
+
+func (_c *Backend_PendingNonceAt_Call) Run(run func(ctx context.Context, account common.Address)) *Backend_PendingNonceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *Backend_PendingNonceAt_Call) Return(v uint64, err error) *Backend_PendingNonceAt_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *Backend_PendingNonceAt_Call) RunAndReturn(run func(ctx context.Context, account common.Address) (uint64, error)) *Backend_PendingNonceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendTransaction provides a mock function for the type Backend
+func (_mock *Backend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	ret := _mock.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendTransaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *types.Transaction) error); ok {
+		r0 = returnFunc(ctx, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Backend_SendTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTransaction'
+type Backend_SendTransaction_Call struct {
+	*mock.Call
+}
+
+// SendTransaction is a helper method to define mock.On call
+//   - ctx
+//   - tx
+func (_e *Backend_Expecter) SendTransaction(ctx interface{}, tx interface{}) *Backend_SendTransaction_Call {
+	return &Backend_SendTransaction_Call{Call: _e.mock.On("SendTransaction", ctx, tx)}
+}
+
+func (_c *Backend_SendTransaction_Call) Run(run func(ctx context.Context, tx *types.Transaction)) *Backend_SendTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*types.Transaction))
+	})
+	return _c
+}
+
+func (_c *Backend_SendTransaction_Call) Return(err error) *Backend_SendTransaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Backend_SendTransaction_Call) RunAndReturn(run func(ctx context.Context, tx *types.Transaction) error) *Backend_SendTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SubscribeFilterLogs provides a mock function for the type Backend
+func (_mock *Backend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	ret := _mock.Called(ctx, q, ch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeFilterLogs")
+	}
+
+	var r0 ethereum.Subscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.FilterQuery, chan<- types.Log) (ethereum.Subscription, error)); ok {
+		return returnFunc(ctx, q, ch)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, ethereum.FilterQuery, chan<- types.Log) ethereum.Subscription); ok {
+		r0 = returnFunc(ctx, q, ch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ethereum.Subscription)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, ethereum.FilterQuery, chan<- types.Log) error); ok {
+		r1 = returnFunc(ctx, q, ch)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_SubscribeFilterLogs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeFilterLogs'
+type Backend_SubscribeFilterLogs_Call struct {
+	*mock.Call
+}
+
+// SubscribeFilterLogs is a helper method to define mock.On call
+//   - ctx
+//   - q
+//   - ch
+func (_e *Backend_Expecter) SubscribeFilterLogs(ctx interface{}, q interface{}, ch interface{}) *Backend_SubscribeFilterLogs_Call {
+	return &Backend_SubscribeFilterLogs_Call{Call: _e.mock.On("SubscribeFilterLogs", ctx, q, ch)}
+}
+
+func (_c *Backend_SubscribeFilterLogs_Call) Run(run func(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log)) *Backend_SubscribeFilterLogs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ethereum.FilterQuery), args[2].(chan<- types.Log))
+	})
+	return _c
+}
+
+func (_c *Backend_SubscribeFilterLogs_Call) Return(subscription ethereum.Subscription, err error) *Backend_SubscribeFilterLogs_Call {
+	_c.Call.Return(subscription, err)
+	return _c
+}
+
+func (_c *Backend_SubscribeFilterLogs_Call) RunAndReturn(run func(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)) *Backend_SubscribeFilterLogs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuggestGasPrice provides a mock function for the type Backend
+func (_mock *Backend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestGasPrice")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*big.Int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_SuggestGasPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestGasPrice'
+type Backend_SuggestGasPrice_Call struct {
+	*mock.Call
+}
+
+// SuggestGasPrice is a helper method to define mock.On call
+//   - ctx
+func (_e *Backend_Expecter) SuggestGasPrice(ctx interface{}) *Backend_SuggestGasPrice_Call {
+	return &Backend_SuggestGasPrice_Call{Call: _e.mock.On("SuggestGasPrice", ctx)}
+}
+
+func (_c *Backend_SuggestGasPrice_Call) Run(run func(ctx context.Context)) *Backend_SuggestGasPrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Backend_SuggestGasPrice_Call) Return(intParam *big.Int, err error) *Backend_SuggestGasPrice_Call {
+	_c.Call.Return(intParam, err)
+	return _c
+}
+
+func (_c *Backend_SuggestGasPrice_Call) RunAndReturn(run func(ctx context.Context) (*big.Int, error)) *Backend_SuggestGasPrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SuggestGasTipCap provides a mock function for the type Backend
+func (_mock *Backend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuggestGasTipCap")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*big.Int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_SuggestGasTipCap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuggestGasTipCap'
+type Backend_SuggestGasTipCap_Call struct {
+	*mock.Call
+}
+
+// SuggestGasTipCap is a helper method to define mock.On call
+//   - ctx
+func (_e *Backend_Expecter) SuggestGasTipCap(ctx interface{}) *Backend_SuggestGasTipCap_Call {
+	return &Backend_SuggestGasTipCap_Call{Call: _e.mock.On("SuggestGasTipCap", ctx)}
+}
+
+func (_c *Backend_SuggestGasTipCap_Call) Run(run func(ctx context.Context)) *Backend_SuggestGasTipCap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Backend_SuggestGasTipCap_Call) Return(intParam *big.Int, err error) *Backend_SuggestGasTipCap_Call {
+	_c.Call.Return(intParam, err)
+	return _c
+}
+
+func (_c *Backend_SuggestGasTipCap_Call) RunAndReturn(run func(ctx context.Context) (*big.Int, error)) *Backend_SuggestGasTipCap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TransactionReceipt provides a mock function for the type Backend
+func (_mock *Backend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	ret := _mock.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransactionReceipt")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Hash) (*types.Receipt, error)); ok {
+		return returnFunc(ctx, txHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Hash) *types.Receipt); ok {
+		r0 = returnFunc(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = returnFunc(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// Backend_TransactionReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TransactionReceipt'
+type Backend_TransactionReceipt_Call struct {
+	*mock.Call
+}
+
+// TransactionReceipt is a helper method to define mock.On call
+//   - ctx
+//   - txHash
+func (_e *Backend_Expecter) TransactionReceipt(ctx interface{}, txHash interface{}) *Backend_TransactionReceipt_Call {
+	return &Backend_TransactionReceipt_Call{Call: _e.mock.On("TransactionReceipt", ctx, txHash)}
+}
+
+func (_c *Backend_TransactionReceipt_Call) Run(run func(ctx context.Context, txHash common.Hash)) *Backend_TransactionReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Hash))
+	})
+	return _c
+}
+
+func (_c *Backend_TransactionReceipt_Call) Return(receipt *types.Receipt, err error) *Backend_TransactionReceipt_Call {
+	_c.Call.Return(receipt, err)
+	return _c
+}
+
+func (_c *Backend_TransactionReceipt_Call) RunAndReturn(run func(ctx context.Context, txHash common.Hash) (*types.Receipt, error)) *Backend_TransactionReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewBalanceReader creates a new instance of BalanceReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBalanceReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BalanceReader {
+	mock := &BalanceReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// BalanceReader is an autogenerated mock type for the BalanceReader type
+type BalanceReader struct {
+	mock.Mock
+}
+
+type BalanceReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BalanceReader) EXPECT() *BalanceReader_Expecter {
+	return &BalanceReader_Expecter{mock: &_m.Mock}
+}
+
+// BalanceAt provides a mock function for the type BalanceReader
+func (_mock *BalanceReader) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	ret := _mock.Called(ctx, account, blockNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BalanceAt")
+	}
+
+	var r0 *big.Int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) (*big.Int, error)); ok {
+		return returnFunc(ctx, account, blockNumber)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int) *big.Int); ok {
+		r0 = returnFunc(ctx, account, blockNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, *big.Int) error); ok {
+		r1 = returnFunc(ctx, account, blockNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BalanceReader_BalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BalanceAt'
+type BalanceReader_BalanceAt_Call struct {
+	*mock.Call
+}
+
+// BalanceAt is a helper method to define mock.On call
+//   - ctx
+//   - account
+//   - blockNumber
+func (_e *BalanceReader_Expecter) BalanceAt(ctx interface{}, account interface{}, blockNumber interface{}) *BalanceReader_BalanceAt_Call {
+	return &BalanceReader_BalanceAt_Call{Call: _e.mock.On("BalanceAt", ctx, account, blockNumber)}
+}
+
+func (_c *BalanceReader_BalanceAt_Call) Run(run func(ctx context.Context, account common.Address, blockNumber *big.Int)) *BalanceReader_BalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(*big.Int))
+	})
+	return _c
+}
+
+func (_c *BalanceReader_BalanceAt_Call) Return(intParam *big.Int, err error) *BalanceReader_BalanceAt_Call {
+	_c.Call.Return(intParam, err)
+	return _c
+}
+
+func (_c *BalanceReader_BalanceAt_Call) RunAndReturn(run func(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)) *BalanceReader_BalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewNonceReader creates a new instance of NonceReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNonceReader(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *NonceReader {
+	mock := &NonceReader{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// NonceReader is an autogenerated mock type for the NonceReader type
+type NonceReader struct {
+	mock.Mock
+}
+
+type NonceReader_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *NonceReader) EXPECT() *NonceReader_Expecter {
+	return &NonceReader_Expecter{mock: &_m.Mock}
+}
+
+// PendingNonceAt provides a mock function for the type NonceReader
+func (_mock *NonceReader) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	ret := _mock.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingNonceAt")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address) (uint64, error)); ok {
+		return returnFunc(ctx, account)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address) uint64); ok {
+		r0 = returnFunc(ctx, account)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = returnFunc(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// NonceReader_PendingNonceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingNonceAt'
+type NonceReader_PendingNonceAt_Call struct {
+	*mock.Call
+}
+
+// PendingNonceAt is a helper method to define mock.On call
+//   - ctx
+//   - account
+func (_e *NonceReader_Expecter) PendingNonceAt(ctx interface{}, account interface{}) *NonceReader_PendingNonceAt_Call {
+	return &NonceReader_PendingNonceAt_Call{Call: _e.mock.On("PendingNonceAt", ctx, account)}
+}
+
+func (_c *NonceReader_PendingNonceAt_Call) Run(run func(ctx context.Context, account common.Address)) *NonceReader_PendingNonceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *NonceReader_PendingNonceAt_Call) Return(v uint64, err error) *NonceReader_PendingNonceAt_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *NonceReader_PendingNonceAt_Call) RunAndReturn(run func(ctx context.Context, account common.Address) (uint64, error)) *NonceReader_PendingNonceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -1,90 +1,211 @@
 package foundry
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
-	DefaultAddress1 = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
-	DefaultAddress2 = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
-	DefaultAddress3 = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
-	DefaultAddress4 = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
-	DefaultAddress5 = "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
-	DefaultAddress6 = "0x9965507D1a55bcC2695C58ba16FB37d819B0A4dc"
-	DefaultAddress7 = "0x976EA74026E726554dB657fA54763abd0C3a0aa9"
-	DefaultAddress8 = "0x14dC79964da2C08b23698B3D3cc7Ca32193d9955"
-	DefaultAddress9  = "0x23618e81E3f5cdF7f54C3d65f7FBc0aBf5B21E8f"
-	DefaultAddress10 = "0xa0Ee7A142d267C1f36714E4a8F75612F20a79720"
-
-	DefaultPrivateKey1 = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
-	DefaultPrivateKey2 = "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
-	DefaultPrivateKey3 = "0x5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
-	DefaultPrivateKey4 = "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
-	DefaultPrivateKey5 = "0x47e179ec197488593b187f80a00eb0da91f1b9d0b13f8733639f19c30a34926a"
-	DefaultPrivateKey6 = "0x8b3a350cf5c34c9194ca85829a2df0ec3153be0318b5e2d3348e872092edffba"
-	DefaultPrivateKey7 = "0x92db14e403b83dfe3df233f83dfa3a0d7096f21ca9b0d6d6b8d88b2b4ec1564e"
-	DefaultPrivateKey8 = "0x4bbbf85ce3377467afe5d46f804f221813b2bb87f24d81f60f1fcdbf7cbf4356"
-	DefaultPrivateKey9  = "0xdbda1821b80551c9d65939329250298aa3472ba22feea921c0cf5d620ea67b97"
-	DefaultPrivateKey10 = "0x2a871d0798f97d79848a013d4936a73bf4cc922c825d33c1cf7073dff6d409c6"
+	// DefaultMnemonic is the BIP-39 mnemonic Anvil derives its dev accounts
+	// from unless started with --mnemonic.
+	DefaultMnemonic = "test test test test test test test test test test test junk"
+
+	// DefaultDerivationPath is the BIP-44 path Anvil derives its dev accounts
+	// along, with the account index appended.
+	//
+	// Example: m/44'/60'/0'/0/0 is the first account.
+	DefaultDerivationPath = "m/44'/60'/0'/0/"
+
+	// mnemonicIterations, mnemonicSeedLength and mnemonicSalt are the PBKDF2
+	// parameters of BIP-39, and masterKeyHMAC the HMAC key of the BIP-32
+	// master key.
+	mnemonicIterations = 2048
+	mnemonicSeedLength = 64
+	mnemonicSalt       = "mnemonic"
+	masterKeyHMAC      = "Bitcoin seed" //nolint:gosec
+	childIndexLength   = 4
+	hardenedKeyStart   = 0x80000000
+	hardenedKeyPrefix  = 0x00
 )
 
 var (
-	DefaultAddresses = []string{
-		DefaultAddress1, DefaultAddress2, DefaultAddress3, DefaultAddress4, DefaultAddress5,
-		DefaultAddress6, DefaultAddress7, DefaultAddress8, DefaultAddress9, DefaultAddress10,
-	}
-	DefaultPrivateKeys = []string{
-		DefaultPrivateKey1, DefaultPrivateKey2, DefaultPrivateKey3, DefaultPrivateKey4, DefaultPrivateKey5,
-		DefaultPrivateKey6, DefaultPrivateKey7, DefaultPrivateKey8, DefaultPrivateKey9, DefaultPrivateKey10,
-	}
-
-	ErrAccount = errors.New("account")
+	ErrAccount         = errors.New("account")
+	ErrAccountMismatch = fmt.Errorf("%w: address does not match private key", ErrAccount)
+	ErrDerivation      = fmt.Errorf("%w: deriving account", ErrAccount)
 )
 
+// BalanceReader reads the balance of an account, like an *ethclient.Client.
+type BalanceReader interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
 type Account struct {
 	address       common.Address
 	privateKey    *ecdsa.PrivateKey
 	privateKeyHex string
-	balance       uint64
 }
 
+// NewDefaultAnvilAccounts returns the NumAccounts dev accounts Anvil funds
+// by default.
 func NewDefaultAnvilAccounts() ([]*Account, error) {
-	accounts := make([]*Account, len(DefaultAddresses))
-	for i, address := range DefaultAddresses {
-		account, err := NewAccount(address, DefaultPrivateKeys[i], StartingBalance)
+	return DeriveAccounts(DefaultMnemonic, DefaultDerivationPath, NumAccounts)
+}
+
+// DeriveAccounts derives n accounts from mnemonic the way Anvil does for its
+// --mnemonic, --derivation-path and --accounts flags: the i-th account uses
+// derivationPath with i appended.
+//
+// The mnemonic's words are not checked against the BIP-39 word list.
+func DeriveAccounts(
+	mnemonic string,
+	derivationPath string,
+	n int,
+) ([]*Account, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("%w: number of accounts must be positive: got %d", ErrDerivation, n)
+	}
+
+	seed, err := mnemonicSeed(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(derivationPath, "/") + "/"
+	derived := make([]*Account, n)
+	for i := range derived {
+		path, err := accounts.ParseDerivationPath(prefix + strconv.Itoa(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDerivation, err)
+		}
+
+		privateKey, err := deriveKey(seed, path)
 		if err != nil {
-			return nil, fmt.Errorf("%w: failed to create account: %w", ErrAccount, err)
+			return nil, err
 		}
-		accounts[i] = account
+
+		derived[i] = newAccount(privateKey)
 	}
-	return accounts, nil
+	return derived, nil
 }
 
-func NewAccount(
+// NewAccount returns the account controlled by the hex-encoded private key.
+func NewAccount(privateKeyHex string) (*Account, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, HexStringPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid private key: %w", ErrAccount, err)
+	}
+	return newAccount(privateKey), nil
+}
+
+// NewAccountWithAddress is NewAccount for a key expected to control address.
+// It returns ErrAccountMismatch if it does not.
+func NewAccountWithAddress(
 	address string,
 	privateKeyHex string,
-	balance uint64,
 ) (*Account, error) {
-	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, HexStringPrefix))
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("%w: invalid address: %s", ErrAccount, address)
+	}
+
+	account, err := NewAccount(privateKeyHex)
 	if err != nil {
-		return nil, fmt.Errorf("%w: invalid private key: %w", ErrAccount, err)
+		return nil, err
 	}
 
-	addr := common.HexToAddress(address)
-	return &Account{
-		address:       addr,
-		privateKey:    privateKey,
-		privateKeyHex: privateKeyHex,
-		balance:       balance,
-	}, nil
+	want := common.HexToAddress(address)
+	if account.address != want {
+		return nil, fmt.Errorf("%w: want %s, got %s", ErrAccountMismatch, want.Hex(), account.address.Hex())
+	}
+	return account, nil
 }
 
-func (a *Account) Address() common.Address      { return a.address }
+func (a *Account) Address() common.Address       { return a.address }
 func (a *Account) PrivateKey() *ecdsa.PrivateKey { return a.privateKey }
 func (a *Account) PrivateKeyHex() string         { return a.privateKeyHex }
+
+// Balance returns the balance of the account, in wei, at the latest block.
+func (a *Account) Balance(ctx context.Context, client BalanceReader) (*big.Int, error) {
+	balance, err := client.BalanceAt(ctx, a.address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: getting balance of %s: %w", ErrAccount, a.address.Hex(), err)
+	}
+	return balance, nil
+}
+
+func newAccount(privateKey *ecdsa.PrivateKey) *Account {
+	return &Account{
+		address:       crypto.PubkeyToAddress(privateKey.PublicKey),
+		privateKey:    privateKey,
+		privateKeyHex: BytesToHexString(crypto.FromECDSA(privateKey)),
+	}
+}
+
+// mnemonicSeed returns the BIP-39 seed of mnemonic, without a passphrase.
+func mnemonicSeed(mnemonic string) ([]byte, error) {
+	words := strings.Join(strings.Fields(mnemonic), " ")
+	if words == "" {
+		return nil, fmt.Errorf("%w: empty mnemonic", ErrDerivation)
+	}
+
+	seed, err := pbkdf2.Key(sha512.New, words, []byte(mnemonicSalt), mnemonicIterations, mnemonicSeedLength)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDerivation, err)
+	}
+	return seed, nil
+}
+
+// deriveKey derives the BIP-32 private key of seed along path.
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, chainCode := hmacSHA512([]byte(masterKeyHMAC), seed)
+	privateKey, err := crypto.ToECDSA(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid master key: %w", ErrDerivation, err)
+	}
+
+	curveOrder := crypto.S256().Params().N
+	for _, index := range path {
+		data := make([]byte, 0, 1+len(key)+childIndexLength)
+		if index >= hardenedKeyStart {
+			data = append(data, hardenedKeyPrefix)
+			data = append(data, key...)
+		} else {
+			data = append(data, crypto.CompressPubkey(&privateKey.PublicKey)...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		var tweak []byte
+		tweak, chainCode = hmacSHA512(chainCode, data)
+		child := new(big.Int).SetBytes(tweak)
+		if child.Cmp(curveOrder) >= 0 {
+			return nil, fmt.Errorf("%w: invalid child key at index %d", ErrDerivation, index)
+		}
+		child.Add(child, privateKey.D).Mod(child, curveOrder)
+
+		key = child.FillBytes(make([]byte, len(key)))
+		privateKey, err = crypto.ToECDSA(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid child key at index %d: %w", ErrDerivation, index, err)
+		}
+	}
+	return privateKey, nil
+}
+
+func hmacSHA512(key []byte, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:len(sum)/2], sum[len(sum)/2:]
+}
@@ -0,0 +1,216 @@
+package foundry_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/mocks"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+const (
+	// abandonMnemonic is the BIP-39 test vector mnemonic, and abandonAddress
+	// and abandonPrivateKey its first account on Ethereum's derivation path.
+	abandonMnemonic   = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	abandonAddress    = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+	abandonPrivateKey = "0x1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727"
+)
+
+// anvilAccounts are the dev accounts Anvil prints on start.
+var anvilAccounts = []struct {
+	address    string
+	privateKey string
+}{
+	{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"},
+	{"0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"},
+	{"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC", "0x5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"},
+	{"0x90F79bf6EB2c4f870365E785982E1f101E93b906", "0x7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"},
+	{"0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65", "0x47e179ec197488593b187f80a00eb0da91f1b9d0b13f8733639f19c30a34926a"},
+	{"0x9965507D1a55bcC2695C58ba16FB37d819B0A4dc", "0x8b3a350cf5c34c9194ca85829a2df0ec3153be0318b5e2d3348e872092edffba"},
+	{"0x976EA74026E726554dB657fA54763abd0C3a0aa9", "0x92db14e403b83dfe3df233f83dfa3a0d7096f21ca9b0d6d6b8d88b2b4ec1564e"},
+	{"0x14dC79964da2C08b23698B3D3cc7Ca32193d9955", "0x4bbbf85ce3377467afe5d46f804f221813b2bb87f24d81f60f1fcdbf7cbf4356"},
+	{"0x23618e81E3f5cdF7f54C3d65f7FBc0aBf5B21E8f", "0xdbda1821b80551c9d65939329250298aa3472ba22feea921c0cf5d620ea67b97"},
+	{"0xa0Ee7A142d267C1f36714E4a8F75612F20a79720", "0x2a871d0798f97d79848a013d4936a73bf4cc922c825d33c1cf7073dff6d409c6"},
+}
+
+func TestNewAccount(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := anvilAccounts[1]
+
+		// when
+		got, err := foundry.NewAccount(want.privateKey)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(want.address), got.Address())
+		require.Equal(t, want.privateKey, got.PrivateKeyHex())
+	})
+
+	t.Run("happy path - no prefix", func(t *testing.T) {
+		// given
+		want := anvilAccounts[1]
+
+		// when
+		got, err := foundry.NewAccount(strings.TrimPrefix(want.privateKey, "0x"))
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(want.address), got.Address())
+		require.Equal(t, want.privateKey, got.PrivateKeyHex())
+	})
+
+	t.Run("error - invalid private key", func(t *testing.T) {
+		// given
+		privateKeyHex := "0x1234"
+
+		// when
+		_, err := foundry.NewAccount(privateKeyHex)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAccount)
+	})
+}
+
+func TestNewAccountWithAddress(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := anvilAccounts[2]
+
+		// when
+		got, err := foundry.NewAccountWithAddress(strings.ToLower(want.address), want.privateKey)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(want.address), got.Address())
+	})
+
+	t.Run("error - mismatch", func(t *testing.T) {
+		// given
+		address, privateKey := anvilAccounts[2].address, anvilAccounts[3].privateKey
+
+		// when
+		_, err := foundry.NewAccountWithAddress(address, privateKey)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAccountMismatch)
+		require.ErrorContains(t, err, anvilAccounts[3].address)
+	})
+
+	t.Run("error - invalid address", func(t *testing.T) {
+		// given
+		address := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb9226"
+
+		// when
+		_, err := foundry.NewAccountWithAddress(address, anvilAccounts[0].privateKey)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAccount)
+		require.NotErrorIs(t, err, foundry.ErrAccountMismatch)
+	})
+}
+
+func TestAccount_Balance(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		want, ok := new(big.Int).SetString("10000000000000000000000", 10)
+		require.True(t, ok)
+
+		client := mocks.NewBalanceReader(t)
+		client.EXPECT().
+			BalanceAt(mock.Anything, account.Address(), (*big.Int)(nil)).
+			Return(want, nil)
+
+		// when
+		got, err := account.Balance(t.Context(), client)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+
+	t.Run("error - client fails", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		client := mocks.NewBalanceReader(t)
+		client.EXPECT().
+			BalanceAt(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errConnectionRefused)
+
+		// when
+		_, err := account.Balance(t.Context(), client)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAccount)
+		require.ErrorIs(t, err, errConnectionRefused)
+	})
+}
+
+func TestDeriveAccounts(t *testing.T) {
+	t.Run("happy path - anvil defaults", func(t *testing.T) {
+		// given
+		n := len(anvilAccounts)
+
+		// when
+		got, err := foundry.DeriveAccounts(foundry.DefaultMnemonic, foundry.DefaultDerivationPath, n)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got, n)
+		for i, want := range anvilAccounts {
+			require.Equal(t, common.HexToAddress(want.address), got[i].Address())
+			require.Equal(t, want.privateKey, got[i].PrivateKeyHex())
+		}
+	})
+
+	t.Run("happy path - mnemonic and path", func(t *testing.T) {
+		// given
+		mnemonic := " abandon abandon abandon abandon abandon abandon\n abandon abandon abandon abandon abandon about "
+
+		// when
+		got, err := foundry.DeriveAccounts(mnemonic, "m/44'/60'/0'/0", 1)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(abandonAddress), got[0].Address())
+		require.Equal(t, abandonPrivateKey, got[0].PrivateKeyHex())
+	})
+
+	t.Run("error - no accounts", func(t *testing.T) {
+		// given
+		n := 0
+
+		// when
+		_, err := foundry.DeriveAccounts(foundry.DefaultMnemonic, foundry.DefaultDerivationPath, n)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDerivation)
+	})
+
+	t.Run("error - empty mnemonic", func(t *testing.T) {
+		// given
+		mnemonic := " "
+
+		// when
+		_, err := foundry.DeriveAccounts(mnemonic, foundry.DefaultDerivationPath, 1)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDerivation)
+	})
+
+	t.Run("error - invalid derivation path", func(t *testing.T) {
+		// given
+		path := "m/44'/sixty'/0'/0/"
+
+		// when
+		_, err := foundry.DeriveAccounts(foundry.DefaultMnemonic, path, 1)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDerivation)
+	})
+}
@@ -1,19 +1,25 @@
 package foundry
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"net"
 	"os"
 	"os/exec"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 const (
@@ -21,9 +27,28 @@ const (
 	ForgeCommand  = "forge"
 	ScriptCommand = "script"
 
-	BroadcastFlag  = "--broadcast"
-	PrivateKeyFlag = "--private-key"
-	RPCFlag        = "--rpc-url"
+	BroadcastFlag    = "--broadcast"
+	KeystoreFlag     = "--keystore"
+	PasswordFileFlag = "--password-file"
+	RPCFlag          = "--rpc-url"
+	SenderFlag       = "--sender"
+	UnlockedFlag     = "--unlocked"
+
+	AccountsFlag         = "--accounts"
+	BalanceFlag          = "--balance"
+	BaseFeeFlag          = "--block-base-fee-per-gas"
+	BlockTimeFlag        = "--block-time"
+	ChainIDFlag          = "--chain-id"
+	DerivationPathFlag   = "--derivation-path"
+	DumpStateFlag        = "--dump-state"
+	GasLimitFlag         = "--gas-limit"
+	GenesisNumberFlag    = "--number"
+	GenesisTimestampFlag = "--timestamp"
+	HardforkFlag         = "--hardfork"
+	HostFlag             = "--host"
+	LoadStateFlag        = "--load-state"
+	MnemonicFlag         = "--mnemonic"
+	PortFlag             = "--port"
 
 	// BroadcastPath is where the `forge script` stores the resulting broadcast file.
 	// It should be: <broadcast_dir>/<script_name>/<chain_id>/run-latest.json
@@ -38,106 +63,476 @@ const (
 	ScriptName = "%s.s.sol"
 
 	// ScriptPath is the script path to use with the `forge script` command.
-	// It should be: <script_dir>/<script_name>:<contract_name>Script
+	// It should be: <script_dir>/<script_name>:<target_contract>
 	//
 	// Example: ../../contracts/scripts/HelloWorld.s.sol:HelloWorldScript
-	ScriptPath = "%s/%s:%sScript"
+	ScriptPath = "%s/%s:%s"
 
 	BaseFee          = 1_000_000_000
 	ChainID          = 31337
 	GasLimit         = 30_000_000
 	GenesisTimestamp = 1769011998
 	GenesisNumber    = 0
+	Host             = "127.0.0.1"
+	NumAccounts      = 10
+	Port             = EphemeralPort
 	StartingBalance  = 10_000
-	URL              = "http://127.0.0.1:8545"
-	WaitTime         = 150 * time.Millisecond
+	PollInterval     = 50 * time.Millisecond
+	StartAttempts    = 3
+	StartTimeout     = 10 * time.Second
+	StopTimeout      = 5 * time.Second
+
+	// EphemeralPort tells Start to pick a free port on the configured host.
+	EphemeralPort = 0
+
+	ChainIDMethod = "eth_chainId"
 )
 
 var (
-	ErrAnvil = errors.New("anvil")
+	broadcastLocks sync.Map
+
+	ErrAnvil         = errors.New("anvil")
+	ErrAnvilExited   = fmt.Errorf("%w: process exited", ErrAnvil)
+	ErrAnvilNotFound = fmt.Errorf("%w: command not found", ErrAnvil)
+	ErrAnvilNotReady = fmt.Errorf("%w: not ready", ErrAnvil)
+	ErrAnvilSigner   = fmt.Errorf("%w: unsupported signer", ErrAnvil)
+	ErrAnvilState    = fmt.Errorf("%w: invalid state", ErrAnvil)
 )
 
+// AnvilState is a step in the lifecycle of an Anvil process. An Anvil moves
+// from idle to starting to running, and ends up stopped no matter how it
+// exits.
+type AnvilState int
+
+const (
+	AnvilIdle AnvilState = iota
+	AnvilStarting
+	AnvilRunning
+	AnvilStopped
+)
+
+func (s AnvilState) String() string {
+	switch s {
+	case AnvilIdle:
+		return "idle"
+	case AnvilStarting:
+		return "starting"
+	case AnvilRunning:
+		return "running"
+	case AnvilStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// AnvilConfig holds the chain parameters passed to the `anvil` process.
+type AnvilConfig struct {
+	BaseFee          uint64
+	Balance          uint64
+	BlockTime        time.Duration
+	ChainID          uint64
+	DerivationPath   string
+	DumpState        string
+	GasLimit         uint64
+	GenesisTimestamp uint64
+	GenesisNumber    uint64
+	Hardfork         string
+	Host             string
+	LoadState        string
+	Mnemonic         string
+	NumAccounts      int
+	Port             int
+	StopTimeout      time.Duration
+}
+
+// DefaultAnvilConfig returns the configuration matching Anvil's own defaults,
+// except for the genesis timestamp which is pinned for reproducibility.
+func DefaultAnvilConfig() *AnvilConfig {
+	return &AnvilConfig{
+		BaseFee:          BaseFee,
+		Balance:          StartingBalance,
+		BlockTime:        0,
+		ChainID:          ChainID,
+		DerivationPath:   DefaultDerivationPath,
+		DumpState:        "",
+		GasLimit:         GasLimit,
+		GenesisTimestamp: GenesisTimestamp,
+		GenesisNumber:    GenesisNumber,
+		Hardfork:         "",
+		Host:             Host,
+		LoadState:        "",
+		Mnemonic:         DefaultMnemonic,
+		NumAccounts:      NumAccounts,
+		Port:             Port,
+		StopTimeout:      StopTimeout,
+	}
+}
+
+// AnvilOption overrides a field of the AnvilConfig used by NewAnvil.
+type AnvilOption func(*AnvilConfig)
+
+// WithBalance sets the starting balance, in ether, of each dev account.
+func WithBalance(balance uint64) AnvilOption {
+	return func(c *AnvilConfig) { c.Balance = balance }
+}
+
+// WithBaseFee sets the base fee per gas, in wei, of the genesis block.
+func WithBaseFee(baseFee uint64) AnvilOption {
+	return func(c *AnvilConfig) { c.BaseFee = baseFee }
+}
+
+// WithBlockTime enables interval mining. A zero duration keeps automine.
+func WithBlockTime(blockTime time.Duration) AnvilOption {
+	return func(c *AnvilConfig) { c.BlockTime = blockTime }
+}
+
+// WithChainID sets the chain ID Anvil reports, via --chain-id.
+func WithChainID(chainID uint64) AnvilOption {
+	return func(c *AnvilConfig) { c.ChainID = chainID }
+}
+
+// WithDerivationPath sets the BIP-44 path the dev accounts are derived
+// along. The account index is appended to it.
+func WithDerivationPath(path string) AnvilOption {
+	return func(c *AnvilConfig) { c.DerivationPath = path }
+}
+
+// WithDumpState makes Anvil write its chain state to path when it stops. The
+// file can be passed to WithLoadState.
+func WithDumpState(path string) AnvilOption {
+	return func(c *AnvilConfig) { c.DumpState = path }
+}
+
+// WithGasLimit sets the block gas limit, via --gas-limit.
+func WithGasLimit(gasLimit uint64) AnvilOption {
+	return func(c *AnvilConfig) { c.GasLimit = gasLimit }
+}
+
+// WithGenesisNumber sets the number of the genesis block, via --number.
+func WithGenesisNumber(number uint64) AnvilOption {
+	return func(c *AnvilConfig) { c.GenesisNumber = number }
+}
+
+// WithGenesisTimestamp sets the Unix timestamp of the genesis block, via
+// --timestamp.
+func WithGenesisTimestamp(timestamp uint64) AnvilOption {
+	return func(c *AnvilConfig) { c.GenesisTimestamp = timestamp }
+}
+
+// WithHardfork sets the EVM hardfork (e.g. "prague"). An empty string keeps
+// Anvil's default.
+func WithHardfork(hardfork string) AnvilOption {
+	return func(c *AnvilConfig) { c.Hardfork = hardfork }
+}
+
+// WithHost sets the address Anvil listens on, via --host.
+func WithHost(host string) AnvilOption {
+	return func(c *AnvilConfig) { c.Host = host }
+}
+
+// WithLoadState starts Anvil from a chain state saved by DumpState or
+// WithDumpState.
+func WithLoadState(path string) AnvilOption {
+	return func(c *AnvilConfig) { c.LoadState = path }
+}
+
+// WithMnemonic sets the BIP-39 mnemonic the dev accounts are derived from.
+func WithMnemonic(mnemonic string) AnvilOption {
+	return func(c *AnvilConfig) { c.Mnemonic = mnemonic }
+}
+
+// WithNumAccounts sets how many dev accounts Anvil derives and funds.
+func WithNumAccounts(n int) AnvilOption {
+	return func(c *AnvilConfig) { c.NumAccounts = n }
+}
+
+// WithPort binds Anvil to a fixed port instead of a free one chosen by Start.
+func WithPort(port int) AnvilOption {
+	return func(c *AnvilConfig) { c.Port = port }
+}
+
+// WithStopTimeout sets how long Stop waits after SIGTERM before sending
+// SIGKILL.
+func WithStopTimeout(timeout time.Duration) AnvilOption {
+	return func(c *AnvilConfig) { c.StopTimeout = timeout }
+}
+
 type Anvil struct {
 	accounts         []*Account
+	balance          uint64
 	baseFee          uint64
+	blockTime        time.Duration
 	chainID          *big.Int
+	derivationPath   string
+	dumpState        string
 	gasLimit         uint64
 	genesisTimestamp uint64
 	genesisNumber    uint64
-	url              string
+	hardfork         string
+	host             string
+	loadState        string
+	mnemonic         string
+	port             int
+	stopTimeout      time.Duration
 	broadcastDir     string
 	scriptDir        string
-	client           *ethclient.Client
-	server           *exec.Cmd
+
+	mu       sync.Mutex
+	state    AnvilState
+	stopping bool
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+	client   *ethclient.Client
+	server   *exec.Cmd
 }
 
 func NewAnvil(
 	broadcastDir string,
 	scriptDir string,
+	opts ...AnvilOption,
 ) (*Anvil, error) {
-	accounts, err := NewDefaultAnvilAccounts()
+	config := DefaultAnvilConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	derivationPath := strings.TrimSuffix(config.DerivationPath, "/") + "/"
+	accounts, err := DeriveAccounts(
+		config.Mnemonic,
+		derivationPath,
+		config.NumAccounts,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: creating accounts: %w", ErrAnvil, err)
 	}
 
 	return &Anvil{
 		accounts:         accounts,
-		baseFee:          BaseFee,
-		chainID:          big.NewInt(ChainID),
-		gasLimit:         GasLimit,
-		genesisTimestamp: GenesisTimestamp,
-		genesisNumber:    GenesisNumber,
-		url:              URL,
+		balance:          config.Balance,
+		baseFee:          config.BaseFee,
+		blockTime:        config.BlockTime,
+		chainID:          new(big.Int).SetUint64(config.ChainID),
+		derivationPath:   derivationPath,
+		dumpState:        config.DumpState,
+		gasLimit:         config.GasLimit,
+		genesisTimestamp: config.GenesisTimestamp,
+		genesisNumber:    config.GenesisNumber,
+		hardfork:         config.Hardfork,
+		host:             config.Host,
+		loadState:        config.LoadState,
+		mnemonic:         config.Mnemonic,
+		port:             config.Port,
+		stopTimeout:      config.StopTimeout,
 		broadcastDir:     broadcastDir,
 		scriptDir:        scriptDir,
-		client:           nil,
-		server:           nil,
+		state:            AnvilIdle,
+		done:             make(chan struct{}),
 	}, nil
 }
 
 func (a *Anvil) Accounts() []*Account     { return a.accounts }
 func (a *Anvil) Account(i int) *Account   { return a.accounts[i] }
+func (a *Anvil) Balance() uint64          { return a.balance }
 func (a *Anvil) BaseFee() uint64          { return a.baseFee }
+func (a *Anvil) BlockTime() time.Duration { return a.blockTime }
 func (a *Anvil) ChainID() *big.Int        { return a.chainID }
+func (a *Anvil) DerivationPath() string   { return a.derivationPath }
 func (a *Anvil) GasLimit() uint64         { return a.gasLimit }
 func (a *Anvil) GenesisTimestamp() uint64 { return a.genesisTimestamp }
 func (a *Anvil) GenesisNumber() uint64    { return a.genesisNumber }
-func (a *Anvil) URL() string              { return a.url }
+func (a *Anvil) Hardfork() string         { return a.hardfork }
+func (a *Anvil) Host() string             { return a.host }
+func (a *Anvil) Mnemonic() string         { return a.mnemonic }
+
+func (a *Anvil) Port() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.port
+}
+
+// URL returns the JSON-RPC endpoint. With an ephemeral port it is only valid
+// once Start has returned.
+func (a *Anvil) URL() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.url()
+}
+
+// Args returns the command-line flags Start passes to the `anvil` process.
+//
+// Example:
+//
+//	anvil --host 127.0.0.1 --port 8545 --chain-id 31337 \
+//	    --block-base-fee-per-gas 1000000000 --gas-limit 30000000 \
+//	    --timestamp 1769011998 --number 0 --accounts 10 --balance 10000
+func (a *Anvil) Args() []string {
+	args := []string{
+		HostFlag, a.host,
+		PortFlag, strconv.Itoa(a.Port()),
+		ChainIDFlag, a.chainID.String(),
+		BaseFeeFlag, strconv.FormatUint(a.baseFee, 10),
+		GasLimitFlag, strconv.FormatUint(a.gasLimit, 10),
+		GenesisTimestampFlag, strconv.FormatUint(a.genesisTimestamp, 10),
+		GenesisNumberFlag, strconv.FormatUint(a.genesisNumber, 10),
+		AccountsFlag, strconv.Itoa(len(a.accounts)),
+		BalanceFlag, strconv.FormatUint(a.balance, 10),
+	}
+	if a.blockTime > 0 {
+		args = append(args, BlockTimeFlag, strconv.FormatFloat(a.blockTime.Seconds(), 'f', -1, 64))
+	}
+	if a.hardfork != "" {
+		args = append(args, HardforkFlag, a.hardfork)
+	}
+	if a.mnemonic != DefaultMnemonic {
+		args = append(args, MnemonicFlag, a.mnemonic)
+	}
+	if a.derivationPath != DefaultDerivationPath {
+		args = append(args, DerivationPathFlag, a.derivationPath)
+	}
+	if a.loadState != "" {
+		args = append(args, LoadStateFlag, a.loadState)
+	}
+	if a.dumpState != "" {
+		args = append(args, DumpStateFlag, a.dumpState)
+	}
+	return args
+}
 
+// Start launches the `anvil` process and blocks until its JSON-RPC endpoint
+// answers `eth_chainId` with the configured chain ID. It gives up when ctx is
+// done, StartTimeout elapses, or the process exits first. Cancelling ctx
+// afterwards stops the process the same way Stop does, and Err reports a
+// clean stop.
+//
+// Without an explicit port, Start binds a free one and retries up to
+// StartAttempts times should another process claim it before Anvil does.
+//
+// An Anvil can only be started once. If Start fails, the Anvil is stopped and
+// Err reports why.
 func (a *Anvil) Start(ctx context.Context, silent bool) error {
-	go func() {
-		a.server = exec.CommandContext(ctx, AnvilCommand)
-		if silent {
-			a.server.Stdout = io.Discard
-			a.server.Stderr = io.Discard
-		} else {
-			a.server.Stdout = os.Stdout
-			a.server.Stderr = os.Stderr
-		}
+	a.mu.Lock()
+	if a.state != AnvilIdle {
+		state := a.state
+		a.mu.Unlock()
+		return fmt.Errorf("%w: cannot start from %s", ErrAnvilState, state)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	a.state = AnvilStarting
+	a.cancel = cancel
+	explicit := a.port != EphemeralPort
+	a.mu.Unlock()
+
+	server, exited, err := a.startWithRetries(ctx, silent, explicit)
+	if err != nil {
+		cancel()
+		a.stopped(err)
+		return err
+	}
 
-		err := a.server.Run()
-		if err != nil && !strings.Contains(err.Error(), "signal: killed") {
-			fmt.Printf("%s: starting anvil: %s\n", ErrAnvil.Error(), err.Error())
+	a.mu.Lock()
+	a.state = AnvilRunning
+	a.server = server
+	a.mu.Unlock()
+
+	go func() {
+		err := <-exited
+		// The process only exits with ctx done if Stop or the caller ended
+		// it, which is a clean stop either way.
+		if ctx.Err() != nil {
+			a.mu.Lock()
+			a.stopping = true
+			a.mu.Unlock()
 		}
+		a.stopped(err)
 	}()
-
-	time.Sleep(WaitTime)
 	return nil
 }
 
+// Stop sends SIGTERM to the `anvil` process, escalates to SIGKILL after the
+// configured stop timeout, and waits for the process to be reaped. It is safe to call
+// Stop more than once and from any goroutine. Stop returns the exit cause
+// only if the process had already died on its own.
 func (a *Anvil) Stop() error {
-	if a.server != nil {
-		return a.server.Process.Kill()
+	a.mu.Lock()
+	switch state := a.state; state {
+	case AnvilIdle:
+		a.stopping = true
+		a.mu.Unlock()
+		a.stopped(nil)
+		return nil
+	case AnvilStopped:
+		err := a.err
+		a.mu.Unlock()
+		return err
+	case AnvilStarting, AnvilRunning:
+		a.stopping = true
+		cancel := a.cancel
+		a.mu.Unlock()
+
+		cancel()
+		<-a.done
+		return nil
+	default:
+		a.mu.Unlock()
+		return fmt.Errorf("%w: unknown state %s", ErrAnvilState, state)
 	}
-	return nil
 }
 
+// Wait blocks until the Anvil has stopped and returns Err.
+func (a *Anvil) Wait() error {
+	<-a.done
+	return a.Err()
+}
+
+// Done returns a channel that is closed once the Anvil has stopped, whether
+// through Stop, a failed Start, or the process exiting on its own.
+func (a *Anvil) Done() <-chan struct{} { return a.done }
+
+// Err returns why the Anvil stopped. It is nil while the Anvil is running and
+// after a clean Stop.
+func (a *Anvil) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+func (a *Anvil) State() AnvilState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.state
+}
+
+// FreePort asks the kernel for a port on host that is not currently bound.
+func FreePort(ctx context.Context, host string) (int, error) {
+	listenConfig := &net.ListenConfig{}
+	listener, err := listenConfig.Listen(ctx, "tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: finding free port: %w", ErrAnvil, err)
+	}
+	defer listener.Close()
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		return 0, fmt.Errorf("%w: unexpected listener address: %s", ErrAnvil, listener.Addr())
+	}
+	return addr.Port, nil
+}
+
+// Client returns an ethclient connected to the running Anvil. The client is
+// cached and closed when the Anvil stops.
 func (a *Anvil) Client() (*ethclient.Client, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.state != AnvilRunning {
+		return nil, fmt.Errorf("%w: no client while %s", ErrAnvilState, a.state)
+	}
 	if a.client != nil {
 		return a.client, nil
 	}
 
-	client, err := ethclient.Dial(a.url)
+	client, err := ethclient.Dial(a.url())
 	if err != nil {
 		return nil, fmt.Errorf("%w: dialing client: %w", ErrAnvil, err)
 	}
@@ -147,50 +542,283 @@ func (a *Anvil) Client() (*ethclient.Client, error) {
 
 // DeployContract deploys a smart contract via the `forge script` command.
 // The command format is:
-// forge script <script_path> --rpc-url <rpc_url> --private-key <private_key> --broadcast
+// forge script <script_path> --rpc-url <rpc_url> <wallet> --broadcast [options]
+//
+// No private key is put on the command line. A KeystoreSigner owner is
+// passed with --keystore and --password-file. A ClefSigner owner is an
+// ErrAnvilSigner, since forge cannot sign through Clef and impersonating it
+// would deploy without Clef approving anything. Any other owner sends
+// through Anvil with --unlocked, which Anvil impersonates for the duration of
+// the script unless it is one of its dev accounts.
 //
 // Example:
 //
 //	forge script ../../contracts/scripts/HelloWorld.s.sol:HelloWorldScript \
 //	    --rpc-url http://127.0.0.1:8545 \
-//	    --private-key 0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80 \
-//	    --broadcast
+//	    --unlocked --sender 0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266 \
+//	    --broadcast \
+//	    --sig "run(uint256)" 42 \
+//	    --slow
 func (a *Anvil) DeployContract(
 	ctx context.Context,
 	contractName string,
-	owner *Account,
-) (*common.Address, error) {
+	owner Signer,
+	opts ...ScriptOption,
+) (*ScriptResult, error) {
+	config := DefaultScriptConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
 	scriptName := fmt.Sprintf(ScriptName, contractName)
-	scriptPath := fmt.Sprintf(ScriptPath, a.scriptDir, scriptName, contractName)
-	args := []string{
-		ScriptCommand,
-		scriptPath,
-		RPCFlag, a.url,
-		PrivateKeyFlag, owner.PrivateKeyHex(),
-		BroadcastFlag,
+	broadcastPath := fmt.Sprintf(BroadcastPath, a.broadcastDir, scriptName, a.chainID)
+
+	// Every Anvil with the same chain ID shares one broadcast file per script,
+	// so concurrent deploys must not interleave writing and reading it.
+	unlock := lockBroadcastPath(broadcastPath)
+	defer unlock()
+
+	wallet, cleanup, err := a.walletArgs(ctx, owner)
+	if err != nil {
+		return nil, err
 	}
+	defer cleanup()
 
+	args := scriptArgs(config, a.scriptDir, contractName, a.URL(), wallet)
 	deploy := exec.CommandContext(ctx, ForgeCommand, args...)
+	deploy.Env = scriptEnv(config)
 	out, err := deploy.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("%w: deploying contract: %w: %s", ErrAnvil, err, string(out))
 	}
 
-	broadcastPath := fmt.Sprintf(BroadcastPath, a.broadcastDir, scriptName, a.chainID)
-	bytes, err := os.ReadFile(broadcastPath)
+	broadcast, err := ReadBroadcast(broadcastPath)
 	if err != nil {
-		return nil, fmt.Errorf("%w: reading broadcast file: %w", ErrAnvil, err)
+		return nil, fmt.Errorf("%w: %w", ErrAnvil, err)
 	}
 
-	broadcast := &Broadcast{}
-	err = json.Unmarshal(bytes, broadcast)
+	address, err := broadcast.GetContractAddress(contractName)
 	if err != nil {
-		return nil, fmt.Errorf("%w: unmarshaling broadcast: %w", ErrAnvil, err)
+		return nil, fmt.Errorf("%w: getting contract address: %w", ErrAnvil, err)
 	}
+	return &ScriptResult{
+		Address:    address,
+		Deployment: NewDeployment(broadcast),
+		Broadcast:  broadcast,
+	}, nil
+}
 
-	address, err := broadcast.GetContractAddress(contractName)
+// BroadcastHistory returns every run of the contract's deploy script on
+// this Anvil's chain ID, including runs against earlier Anvil instances.
+func (a *Anvil) BroadcastHistory(contractName string) (*BroadcastHistory, error) {
+	scriptName := fmt.Sprintf(ScriptName, contractName)
+	return ReadBroadcastHistory(a.broadcastDir, scriptName, a.chainID.Uint64())
+}
+
+// walletArgs returns the flags `forge script` sends as owner with, and a
+// function undoing what it took to set them up.
+func (a *Anvil) walletArgs(ctx context.Context, owner Signer) ([]string, func(), error) {
+	switch signer := owner.(type) {
+	case *KeystoreSigner:
+		passwordFile, err := writePasswordFile(signer.passphrase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrAnvil, err)
+		}
+		args := []string{KeystoreFlag, signer.path, PasswordFileFlag, passwordFile}
+		return args, func() { _ = os.Remove(passwordFile) }, nil
+	case *ClefSigner:
+		return nil, nil, fmt.Errorf("%w: forge cannot sign with Clef account %s", ErrAnvilSigner, signer.Address().Hex())
+	}
+
+	address := owner.Address()
+	args := []string{UnlockedFlag, SenderFlag, address.Hex()}
+	if slices.ContainsFunc(a.accounts, func(account *Account) bool { return account.Address() == address }) {
+		return args, func() {}, nil
+	}
+
+	err := a.ImpersonateAccount(ctx, address)
 	if err != nil {
-		return nil, fmt.Errorf("%w: getting contract address: %w", ErrAnvil, err)
+		return nil, nil, err
+	}
+	stop := func() { _ = a.StopImpersonatingAccount(context.WithoutCancel(ctx), address) }
+	return args, stop, nil
+}
+
+func (a *Anvil) url() string {
+	return "http://" + net.JoinHostPort(a.host, strconv.Itoa(a.port))
+}
+
+// stopped moves the Anvil to its terminal state, records the exit cause
+// unless Stop asked for the exit, and releases the cached client.
+func (a *Anvil) stopped(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.state == AnvilStopped {
+		return
+	}
+
+	switch {
+	case a.stopping:
+		a.err = nil
+	case err != nil && !errors.Is(err, ErrAnvil):
+		a.err = fmt.Errorf("%w: %w", ErrAnvilExited, err)
+	case err != nil:
+		a.err = err
+	default:
+		a.err = ErrAnvilExited
+	}
+
+	if a.client != nil {
+		a.client.Close()
+		a.client = nil
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.state = AnvilStopped
+	close(a.done)
+}
+
+func (a *Anvil) startWithRetries(
+	ctx context.Context,
+	silent bool,
+	explicit bool,
+) (*exec.Cmd, <-chan error, error) {
+	if explicit {
+		return a.start(ctx, silent)
+	}
+
+	var (
+		server *exec.Cmd
+		exited <-chan error
+		err    error
+	)
+	for range StartAttempts {
+		var port int
+		port, err = FreePort(ctx, a.Host())
+		if err != nil {
+			break
+		}
+		a.setPort(port)
+
+		server, exited, err = a.start(ctx, silent)
+		if !errors.Is(err, ErrAnvilExited) {
+			break
+		}
+	}
+	if err != nil {
+		a.setPort(EphemeralPort)
+	}
+	return server, exited, err
+}
+
+// start runs a single `anvil` process and waits for it to become ready. On
+// success, the returned channel yields the result of reaping the process.
+func (a *Anvil) start(ctx context.Context, silent bool) (*exec.Cmd, <-chan error, error) {
+	args := a.Args()
+	server := exec.CommandContext(ctx, AnvilCommand, args...)
+	server.Cancel = func() error { return server.Process.Signal(syscall.SIGTERM) }
+	server.WaitDelay = a.stopTimeout
+
+	stderr := &bytes.Buffer{}
+	if silent {
+		server.Stdout = io.Discard
+		server.Stderr = stderr
+	} else {
+		server.Stdout = os.Stdout
+		server.Stderr = os.Stderr
+	}
+
+	err := server.Start()
+	switch {
+	case errors.Is(err, exec.ErrNotFound):
+		return nil, nil, fmt.Errorf("%w: %w", ErrAnvilNotFound, err)
+	case err != nil:
+		return nil, nil, fmt.Errorf("%w: starting anvil: %w", ErrAnvil, err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- server.Wait() }()
+
+	err = a.waitReady(ctx, exited)
+	if errors.Is(err, ErrAnvilExited) && stderr.Len() > 0 {
+		return nil, nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if errors.Is(err, ErrAnvilExited) {
+		return nil, nil, err
+	}
+	if err != nil {
+		_ = server.Process.Kill()
+		<-exited
+		return nil, nil, err
+	}
+	return server, exited, nil
+}
+
+func (a *Anvil) setPort(port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.port = port
+}
+
+// waitReady polls the `eth_chainId` endpoint every PollInterval until it
+// reports the configured chain ID. Checking the chain ID guards against
+// mistaking another node on the same port for this one.
+func (a *Anvil) waitReady(ctx context.Context, exited <-chan error) error {
+	ctx, cancel := context.WithTimeout(ctx, StartTimeout)
+	defer cancel()
+
+	client, err := rpc.DialContext(ctx, a.URL())
+	if err != nil {
+		return fmt.Errorf("%w: dialing rpc: %w", ErrAnvil, err)
+	}
+	defer client.Close()
+
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err = <-exited:
+			return fmt.Errorf("%w: %w", ErrAnvilExited, err)
+		default:
+		}
+
+		chainID := &hexutil.Big{}
+		err = client.CallContext(ctx, chainID, ChainIDMethod)
+		if err == nil && chainID.ToInt().Cmp(a.chainID) == 0 {
+			return nil
+		}
+
+		select {
+		case err = <-exited:
+			return fmt.Errorf("%w: %w", ErrAnvilExited, err)
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ErrAnvilNotReady, context.Cause(ctx))
+		case <-ticker.C:
+		}
+	}
+}
+
+func lockBroadcastPath(path string) func() {
+	mu, _ := broadcastLocks.LoadOrStore(path, &sync.Mutex{})
+	lock, _ := mu.(*sync.Mutex)
+	lock.Lock()
+	return lock.Unlock
+}
+
+// writePasswordFile writes passphrase to a file only the current user can
+// read, for forge's --password-file.
+func writePasswordFile(passphrase string) (string, error) {
+	file, err := os.CreateTemp("", "forge-password-*")
+	if err != nil {
+		return "", fmt.Errorf("creating password file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(passphrase)
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", fmt.Errorf("writing password file: %w", err)
 	}
-	return address, nil
+	return file.Name(), nil
 }
@@ -0,0 +1,460 @@
+package foundry_test
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+const (
+	broadcastDir = "broadcast"
+	scriptDir    = "scripts"
+)
+
+func TestNewAnvil(t *testing.T) {
+	t.Run("happy path - defaults", func(t *testing.T) {
+		// given
+		want := []string{
+			foundry.HostFlag, "127.0.0.1",
+			foundry.PortFlag, "0",
+			foundry.ChainIDFlag, "31337",
+			foundry.BaseFeeFlag, "1000000000",
+			foundry.GasLimitFlag, "30000000",
+			foundry.GenesisTimestampFlag, "1769011998",
+			foundry.GenesisNumberFlag, "0",
+			foundry.AccountsFlag, "10",
+			foundry.BalanceFlag, "10000",
+		}
+
+		// when
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, want, anvil.Args())
+		require.Equal(t, foundry.EphemeralPort, anvil.Port())
+		require.Len(t, anvil.Accounts(), foundry.NumAccounts)
+	})
+
+	t.Run("happy path - options", func(t *testing.T) {
+		// given
+		want := []string{
+			foundry.HostFlag, "0.0.0.0",
+			foundry.PortFlag, "9545",
+			foundry.ChainIDFlag, "1",
+			foundry.BaseFeeFlag, "7",
+			foundry.GasLimitFlag, "60000000",
+			foundry.GenesisTimestampFlag, "1700000000",
+			foundry.GenesisNumberFlag, "100",
+			foundry.AccountsFlag, "3",
+			foundry.BalanceFlag, "5",
+			foundry.BlockTimeFlag, "1.5",
+			foundry.HardforkFlag, "prague",
+			foundry.MnemonicFlag, abandonMnemonic,
+			foundry.DerivationPathFlag, "m/44'/60'/1'/0/",
+			foundry.LoadStateFlag, "in.json",
+			foundry.DumpStateFlag, "out.json",
+		}
+
+		// when
+		anvil, err := foundry.NewAnvil(
+			broadcastDir,
+			scriptDir,
+			foundry.WithHost("0.0.0.0"),
+			foundry.WithPort(9545),
+			foundry.WithChainID(1),
+			foundry.WithBaseFee(7),
+			foundry.WithGasLimit(60_000_000),
+			foundry.WithGenesisTimestamp(1_700_000_000),
+			foundry.WithGenesisNumber(100),
+			foundry.WithNumAccounts(3),
+			foundry.WithBalance(5),
+			foundry.WithBlockTime(1500*time.Millisecond),
+			foundry.WithHardfork("prague"),
+			foundry.WithMnemonic(abandonMnemonic),
+			foundry.WithDerivationPath("m/44'/60'/1'/0"),
+			foundry.WithLoadState("in.json"),
+			foundry.WithDumpState("out.json"),
+		)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, want, anvil.Args())
+		require.Equal(t, "http://0.0.0.0:9545", anvil.URL())
+		require.Equal(t, int64(1), anvil.ChainID().Int64())
+		require.Equal(t, uint64(7), anvil.BaseFee())
+		require.Equal(t, uint64(60_000_000), anvil.GasLimit())
+		require.Equal(t, uint64(1_700_000_000), anvil.GenesisTimestamp())
+		require.Equal(t, uint64(100), anvil.GenesisNumber())
+		require.Len(t, anvil.Accounts(), 3)
+		require.Equal(t, abandonMnemonic, anvil.Mnemonic())
+		require.Equal(t, "m/44'/60'/1'/0/", anvil.DerivationPath())
+	})
+
+	t.Run("happy path - many accounts", func(t *testing.T) {
+		// given
+		numAccounts := 100
+
+		// when
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir, foundry.WithNumAccounts(numAccounts))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, anvil.Accounts(), numAccounts)
+		require.Equal(t, common.HexToAddress(anvilAccounts[0].address), anvil.Account(0).Address())
+		require.NotEqual(t, anvil.Account(98).Address(), anvil.Account(99).Address())
+	})
+
+	t.Run("error - no accounts", func(t *testing.T) {
+		// given
+		numAccounts := 0
+
+		// when
+		_, err := foundry.NewAnvil(broadcastDir, scriptDir, foundry.WithNumAccounts(numAccounts))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvil)
+		require.ErrorIs(t, err, foundry.ErrDerivation)
+	})
+}
+
+func TestAnvil_Start(t *testing.T) {
+	t.Run("happy path - ready", func(t *testing.T) {
+		// given
+		installFakeAnvil(t, "exec sleep 30")
+		fake := startFakeRPC(t, nil)
+
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir, foundry.WithPort(fake.port))
+		require.NoError(t, err)
+
+		// when
+		err = anvil.Start(t.Context(), true)
+
+		// then
+		require.NoError(t, err)
+		require.NoError(t, anvil.Stop())
+	})
+
+	t.Run("error - command not found", func(t *testing.T) {
+		// given
+		t.Setenv("PATH", t.TempDir())
+
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir)
+		require.NoError(t, err)
+
+		// when
+		err = anvil.Start(t.Context(), true)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilNotFound)
+	})
+
+	t.Run("error - process exits early", func(t *testing.T) {
+		// given
+		installFakeAnvil(t, "echo 'address already in use' >&2; exit 1")
+
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir)
+		require.NoError(t, err)
+
+		// when
+		err = anvil.Start(t.Context(), true)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilExited)
+		require.ErrorContains(t, err, "address already in use")
+		require.Equal(t, foundry.EphemeralPort, anvil.Port())
+	})
+
+	t.Run("error - never ready", func(t *testing.T) {
+		// given
+		installFakeAnvil(t, "exec sleep 30")
+		fake := startFakeRPC(t, map[string]any{foundry.ChainIDMethod: "0x1"})
+
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir, foundry.WithPort(fake.port))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+
+		// when
+		err = anvil.Start(ctx, true)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilNotReady)
+	})
+}
+
+func TestAnvil_Stop(t *testing.T) {
+	t.Run("happy path - graceful", func(t *testing.T) {
+		// given
+		anvil := startFakeAnvil(t, "exec sleep 30")
+		require.Equal(t, foundry.AnvilRunning, anvil.State())
+
+		// when
+		err := anvil.Stop()
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, foundry.AnvilStopped, anvil.State())
+		require.NoError(t, anvil.Err())
+		requireClosed(t, anvil.Done())
+	})
+
+	t.Run("happy path - kill after timeout", func(t *testing.T) {
+		// given
+		anvil := startFakeAnvil(
+			t,
+			"trap '' TERM; while :; do sleep 0.1; done",
+			foundry.WithStopTimeout(200*time.Millisecond),
+		)
+
+		// when
+		err := anvil.Stop()
+
+		// then
+		require.NoError(t, err)
+		requireClosed(t, anvil.Done())
+	})
+
+	t.Run("happy path - idempotent", func(t *testing.T) {
+		// given
+		anvil := startFakeAnvil(t, "exec sleep 30")
+		require.NoError(t, anvil.Stop())
+
+		// when
+		err := anvil.Stop()
+
+		// then
+		require.NoError(t, err)
+	})
+
+	t.Run("happy path - never started", func(t *testing.T) {
+		// given
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir)
+		require.NoError(t, err)
+
+		// when
+		err = anvil.Stop()
+
+		// then
+		require.NoError(t, err)
+		requireClosed(t, anvil.Done())
+
+		_, err = anvil.Client()
+		require.ErrorIs(t, err, foundry.ErrAnvilState)
+	})
+
+	t.Run("happy path - context cancelled", func(t *testing.T) {
+		// given
+		installFakeAnvil(t, "exec sleep 30")
+		fake := startFakeRPC(t, nil)
+
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir, foundry.WithPort(fake.port))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		require.NoError(t, anvil.Start(ctx, true))
+
+		// when
+		cancel()
+
+		// then
+		require.NoError(t, anvil.Wait())
+		require.Equal(t, foundry.AnvilStopped, anvil.State())
+		require.NoError(t, anvil.Stop())
+	})
+
+	t.Run("error - process exited on its own", func(t *testing.T) {
+		// given
+		anvil := startFakeAnvil(t, "sleep 0.2; exit 3")
+		require.ErrorIs(t, anvil.Wait(), foundry.ErrAnvilExited)
+
+		// when
+		err := anvil.Stop()
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilExited)
+		require.Equal(t, foundry.AnvilStopped, anvil.State())
+	})
+
+	t.Run("error - cannot restart", func(t *testing.T) {
+		// given
+		anvil := startFakeAnvil(t, "exec sleep 30")
+		require.NoError(t, anvil.Stop())
+
+		// when
+		err := anvil.Start(t.Context(), true)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilState)
+	})
+}
+
+func TestFreePort(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		host := foundry.Host
+
+		// when
+		port, err := foundry.FreePort(t.Context(), host)
+
+		// then
+		require.NoError(t, err)
+		require.NotEqual(t, foundry.EphemeralPort, port)
+
+		listenConfig := &net.ListenConfig{}
+		listener, err := listenConfig.Listen(t.Context(), "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		require.NoError(t, err)
+		require.NoError(t, listener.Close())
+	})
+}
+
+// startFakeAnvil starts an Anvil whose process runs body and whose JSON-RPC
+// endpoint is served by a fake that reports the default chain ID.
+func startFakeAnvil(t *testing.T, body string, opts ...foundry.AnvilOption) *foundry.Anvil {
+	t.Helper()
+	anvil, _ := startFakeAnvilRPC(t, body, nil, opts...)
+	return anvil
+}
+
+// startFakeAnvilRPC is startFakeAnvil with control over the fake endpoint's
+// results.
+func startFakeAnvilRPC(
+	t *testing.T,
+	body string,
+	results map[string]any,
+	opts ...foundry.AnvilOption,
+) (*foundry.Anvil, *fakeRPC) {
+	t.Helper()
+	installFakeAnvil(t, body)
+	fake := startFakeRPC(t, results)
+
+	opts = append(opts, foundry.WithPort(fake.port))
+	anvil, err := foundry.NewAnvil(broadcastDir, scriptDir, opts...)
+	require.NoError(t, err)
+	require.NoError(t, anvil.Start(t.Context(), true))
+	t.Cleanup(func() { _ = anvil.Stop() })
+	return anvil, fake
+}
+
+func requireClosed(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	default:
+		require.Fail(t, "channel not closed")
+	}
+}
+
+// installFakeAnvil puts an `anvil` shell script running body first on PATH.
+func installFakeAnvil(t *testing.T, body string) {
+	t.Helper()
+	installFakeCommand(t, foundry.AnvilCommand, body)
+}
+
+// installFakeCommand puts a shell script named name, running body, first on
+// the PATH.
+func installFakeCommand(t *testing.T, name string, body string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" + body + "\n"
+	//nolint:gosec
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// fakeRPC is a JSON-RPC endpoint that answers each method with a canned
+// result and records the calls it receives.
+type fakeRPC struct {
+	mu      sync.Mutex
+	results map[string]any
+	calls   []fakeCall
+	port    int
+}
+
+type fakeCall struct {
+	Method string
+	Params []json.RawMessage
+}
+
+// fakeRPCError makes the fake answer a method with a JSON-RPC error.
+type fakeRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// startFakeRPC serves a fake JSON-RPC endpoint. It reports the default chain
+// ID unless results overrides eth_chainId.
+func startFakeRPC(t *testing.T, results map[string]any) *fakeRPC {
+	t.Helper()
+	fake := &fakeRPC{results: map[string]any{foundry.ChainIDMethod: "0x7a69"}}
+	maps.Copy(fake.results, results)
+
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+
+	addr, ok := server.Listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	fake.port = addr.Port
+	return fake
+}
+
+func (f *fakeRPC) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}{}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeCall{Method: req.Method, Params: req.Params})
+	result, ok := f.results[req.Method]
+	f.mu.Unlock()
+
+	resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	switch res := result.(type) {
+	case fakeRPCError:
+		resp["error"] = res
+	default:
+		resp["result"] = res
+	}
+	if !ok {
+		delete(resp, "result")
+		resp["error"] = fakeRPCError{Code: -32601, Message: "method not found"}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// lastCall returns the most recent call to method.
+func (f *fakeRPC) lastCall(t *testing.T, method string) fakeCall {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.calls) - 1; i >= 0; i-- {
+		if f.calls[i].Method == method {
+			return f.calls[i]
+		}
+	}
+	require.Failf(t, "method not called", "%s", method)
+	return fakeCall{}
+}
@@ -0,0 +1,337 @@
+package foundry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ArtifactPath is where `forge build` stores a contract's compiled artifact.
+	// It should be: <out_dir>/<contract_name>.sol/<contract_name>.json
+	//
+	// Example: ../../contracts/out/BearCoin.sol/BearCoin.json
+	ArtifactPath = "%s/%s.sol/%s.json"
+
+	// ArtifactGlob matches the artifact of a contract declared in any source
+	// file: <out_dir>/*/<contract_name>.json
+	ArtifactGlob = "%s/*/%s.json"
+)
+
+var (
+	ErrArtifact          = errors.New("artifact")
+	ErrArtifactAmbiguous = fmt.Errorf("%w: contract name is ambiguous", ErrArtifact)
+	ErrArtifactNotFound  = fmt.Errorf("%w: not found", ErrArtifact)
+	ErrArtifactUnlinked  = fmt.Errorf("%w: unlinked library", ErrArtifact)
+
+	// linkPlaceholder matches the `__$<hash>$__` solc leaves in bytecode
+	// where a library address has to be linked in.
+	linkPlaceholder = regexp.MustCompile(`__\$[0-9a-fA-F]{34}\$__`)
+)
+
+// BytecodeRange is a span of bytes within bytecode.
+type BytecodeRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// LinkReferences are the spans of bytecode that hold library addresses, keyed
+// by source file and then by library name.
+type LinkReferences map[string]map[string][]BytecodeRange
+
+// Artifact is a contract compiled by `forge build`.
+//
+// Bytecode and DeployedBytecode are the creation and runtime code. Library
+// addresses are left as zeros until the bytecode is linked with Link.
+type Artifact struct {
+	ABI                    abi.ABI
+	Bytecode               []byte
+	DeployedBytecode       []byte
+	LinkReferences         LinkReferences
+	DeployedLinkReferences LinkReferences
+	// ImmutableReferences are the spans of DeployedBytecode, keyed by AST
+	// node ID, that the constructor fills with the values of immutables.
+	ImmutableReferences map[string][]BytecodeRange
+	// MethodIdentifiers maps function signatures, like "transfer(address,uint256)",
+	// to their hex-encoded selectors.
+	MethodIdentifiers map[string]string
+	SourceMap         []SourceMapEntry
+	DeployedSourceMap []SourceMapEntry
+	// StorageLayout is only present if forge was configured with
+	// `extra_output = ["storageLayout"]`.
+	StorageLayout *StorageLayout
+	Metadata      *ArtifactMetadata
+	// ID is the index of the contract's source file in the build info.
+	ID uint64
+}
+
+// StorageLayout is where the state variables of a contract live in storage.
+//
+//nolint:tagliatelle
+type StorageLayout struct {
+	Storage []*StorageSlot          `json:"storage"`
+	Types   map[string]*StorageType `json:"types"`
+}
+
+// StorageSlot is a state variable. Slot is a decimal string as it may not fit
+// in a uint64.
+//
+//nolint:tagliatelle
+type StorageSlot struct {
+	ASTID    uint64 `json:"astId"`
+	Contract string `json:"contract"`
+	Label    string `json:"label"`
+	Offset   uint64 `json:"offset"`
+	Slot     string `json:"slot"`
+	Type     string `json:"type"`
+}
+
+// StorageType describes a type referenced by StorageSlot.Type. Key and Value
+// are set for mappings, Base for arrays and Members for structs.
+//
+//nolint:tagliatelle
+type StorageType struct {
+	Encoding      string         `json:"encoding"`
+	Label         string         `json:"label"`
+	NumberOfBytes string         `json:"numberOfBytes"`
+	Key           string         `json:"key,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Base          string         `json:"base,omitempty"`
+	Members       []*StorageSlot `json:"members,omitempty"`
+}
+
+// Slot returns the state variable named label, or nil.
+func (l *StorageLayout) Slot(label string) *StorageSlot {
+	for _, slot := range l.Storage {
+		if slot.Label == label {
+			return slot
+		}
+	}
+	return nil
+}
+
+// ArtifactMetadata is the solc metadata of a contract, as embedded by forge.
+//
+//nolint:tagliatelle
+type ArtifactMetadata struct {
+	Compiler struct {
+		Version string `json:"version"`
+	} `json:"compiler"`
+	Language string                     `json:"language"`
+	Output   json.RawMessage            `json:"output"`
+	Settings *MetadataSettings          `json:"settings"`
+	Sources  map[string]*MetadataSource `json:"sources"`
+	Version  uint64                     `json:"version"`
+}
+
+// MetadataSettings are the compiler settings the contract was built with.
+//
+//nolint:tagliatelle
+type MetadataSettings struct {
+	CompilationTarget map[string]string  `json:"compilationTarget"`
+	EVMVersion        string             `json:"evmVersion"`
+	Libraries         map[string]string  `json:"libraries"`
+	Optimizer         *MetadataOptimizer `json:"optimizer"`
+	Remappings        []string           `json:"remappings"`
+}
+
+type MetadataOptimizer struct {
+	Enabled bool   `json:"enabled"`
+	Runs    uint64 `json:"runs"`
+}
+
+// MetadataSource is a source file the contract was compiled from.
+type MetadataSource struct {
+	Keccak256 string   `json:"keccak256"`
+	License   string   `json:"license,omitempty"`
+	URLs      []string `json:"urls,omitempty"`
+}
+
+//nolint:tagliatelle
+type bytecodeJSON struct {
+	Object              string                     `json:"object"`
+	SourceMap           string                     `json:"sourceMap"`
+	LinkReferences      LinkReferences             `json:"linkReferences"`
+	ImmutableReferences map[string][]BytecodeRange `json:"immutableReferences,omitempty"`
+}
+
+//nolint:tagliatelle
+type artifactJSON struct {
+	ABI               json.RawMessage   `json:"abi"`
+	Bytecode          bytecodeJSON      `json:"bytecode"`
+	DeployedBytecode  bytecodeJSON      `json:"deployedBytecode"`
+	MethodIdentifiers map[string]string `json:"methodIdentifiers"`
+	StorageLayout     *StorageLayout    `json:"storageLayout"`
+	Metadata          *ArtifactMetadata `json:"metadata"`
+	ID                uint64            `json:"id"`
+}
+
+// LoadArtifact reads the artifact of contractName from outDir. A contract
+// declared in a file of another name is found by searching every source
+// file's directory, unless contractName is qualified with its file as in
+// "Factory.sol:Pair".
+func LoadArtifact(outDir string, contractName string) (*Artifact, error) {
+	path, err := findArtifact(outDir, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading artifact: %w", ErrArtifact, err)
+	}
+
+	artifact := &Artifact{}
+	err = json.Unmarshal(data, artifact)
+	if err != nil {
+		return nil, err
+	}
+	return artifact, nil
+}
+
+// NeedsLinking reports whether the creation bytecode references libraries.
+func (a *Artifact) NeedsLinking() bool {
+	return len(a.LinkReferences) > 0
+}
+
+// Link returns a copy of the artifact with library addresses written into its
+// bytecode. Libraries are keyed by "<source_file>:<library_name>", as in
+// Broadcast.Libraries, or by library name alone.
+func (a *Artifact) Link(libraries map[string]common.Address) (*Artifact, error) {
+	bytecode, err := link(a.Bytecode, a.LinkReferences, libraries)
+	if err != nil {
+		return nil, err
+	}
+	deployedBytecode, err := link(a.DeployedBytecode, a.DeployedLinkReferences, libraries)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := *a
+	linked.Bytecode = bytecode
+	linked.DeployedBytecode = deployedBytecode
+	linked.LinkReferences = nil
+	linked.DeployedLinkReferences = nil
+	return &linked, nil
+}
+
+// Selector returns the selector of the function with the given signature.
+func (a *Artifact) Selector(signature string) ([]byte, error) {
+	selector, ok := a.MethodIdentifiers[signature]
+	if !ok {
+		return nil, fmt.Errorf("%w: no method %s", ErrArtifact, signature)
+	}
+	return ParseBytesFromHexString(selector)
+}
+
+func (a *Artifact) UnmarshalJSON(data []byte) error {
+	artifact := artifactJSON{}
+	err := json.Unmarshal(data, &artifact)
+	if err != nil {
+		return fmt.Errorf("%w: unmarshaling artifact: %w", ErrArtifact, err)
+	}
+
+	contractABI, err := abi.JSON(bytes.NewReader(artifact.ABI))
+	if err != nil {
+		return fmt.Errorf("%w: parsing abi: %w", ErrArtifact, err)
+	}
+
+	bytecode, err := parseBytecode(artifact.Bytecode.Object)
+	if err != nil {
+		return fmt.Errorf("%w: parsing bytecode: %w", ErrArtifact, err)
+	}
+
+	deployedBytecode, err := parseBytecode(artifact.DeployedBytecode.Object)
+	if err != nil {
+		return fmt.Errorf("%w: parsing deployed bytecode: %w", ErrArtifact, err)
+	}
+
+	sourceMap, err := ParseSourceMap(artifact.Bytecode.SourceMap)
+	if err != nil {
+		return fmt.Errorf("%w: parsing source map: %w", ErrArtifact, err)
+	}
+
+	deployedSourceMap, err := ParseSourceMap(artifact.DeployedBytecode.SourceMap)
+	if err != nil {
+		return fmt.Errorf("%w: parsing deployed source map: %w", ErrArtifact, err)
+	}
+
+	a.ABI = contractABI
+	a.Bytecode = bytecode
+	a.DeployedBytecode = deployedBytecode
+	a.LinkReferences = artifact.Bytecode.LinkReferences
+	a.DeployedLinkReferences = artifact.DeployedBytecode.LinkReferences
+	a.ImmutableReferences = artifact.DeployedBytecode.ImmutableReferences
+	a.MethodIdentifiers = artifact.MethodIdentifiers
+	a.SourceMap = sourceMap
+	a.DeployedSourceMap = deployedSourceMap
+	a.StorageLayout = artifact.StorageLayout
+	a.Metadata = artifact.Metadata
+	a.ID = artifact.ID
+	return nil
+}
+
+func findArtifact(outDir string, contractName string) (string, error) {
+	if file, name, ok := strings.Cut(contractName, ":"); ok {
+		return filepath.Join(outDir, filepath.Base(file), name+".json"), nil
+	}
+
+	path := fmt.Sprintf(ArtifactPath, outDir, contractName, contractName)
+	_, err := os.Stat(path)
+	if err == nil {
+		return path, nil
+	}
+
+	matches, globErr := filepath.Glob(fmt.Sprintf(ArtifactGlob, outDir, contractName))
+	switch {
+	case globErr != nil:
+		return "", fmt.Errorf("%w: searching artifact: %w", ErrArtifact, globErr)
+	case len(matches) == 0:
+		return "", fmt.Errorf("%w: %s: %w", ErrArtifactNotFound, contractName, err)
+	case len(matches) > 1:
+		return "", fmt.Errorf("%w: %s in %s", ErrArtifactAmbiguous, contractName, strings.Join(matches, ", "))
+	}
+	return matches[0], nil
+}
+
+// parseBytecode decodes a bytecode object, leaving zeros where libraries have
+// to be linked in.
+func parseBytecode(object string) ([]byte, error) {
+	zeros := strings.Repeat("00", common.AddressLength)
+	return ParseBytesFromHexString(linkPlaceholder.ReplaceAllString(object, zeros))
+}
+
+func link(
+	bytecode []byte,
+	references LinkReferences,
+	libraries map[string]common.Address,
+) ([]byte, error) {
+	linked := bytes.Clone(bytecode)
+	for file, names := range references {
+		for name, ranges := range names {
+			address, ok := libraries[file+":"+name]
+			if !ok {
+				address, ok = libraries[name]
+			}
+			if !ok {
+				return nil, fmt.Errorf("%w: %s:%s", ErrArtifactUnlinked, file, name)
+			}
+
+			for _, r := range ranges {
+				if r.Start < 0 || r.Length != common.AddressLength || r.Start+r.Length > len(linked) {
+					return nil, fmt.Errorf("%w: invalid link reference to %s:%s", ErrArtifact, file, name)
+				}
+				copy(linked[r.Start:], address.Bytes())
+			}
+		}
+	}
+	return linked, nil
+}
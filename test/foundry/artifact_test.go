@@ -0,0 +1,262 @@
+package foundry_test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+//go:embed testdata/artifact.json
+var artifactJSON []byte
+
+//go:embed testdata/artifact-linked.json
+var linkedArtifactJSON []byte
+
+const (
+	libraryAddress = "0x5fbdb2315678afecb367f032d93f642f64180aa3"
+	pairMath       = "src/libraries/PairMath.sol:PairMath"
+)
+
+func TestArtifact_JSON(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		artifact := &foundry.Artifact{}
+
+		// when
+		err := json.Unmarshal(artifactJSON, artifact)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, artifact.ABI.Methods, "transfer")
+		require.NotEmpty(t, artifact.Bytecode)
+		require.NotEmpty(t, artifact.DeployedBytecode)
+		require.Equal(t, "a9059cbb", artifact.MethodIdentifiers["transfer(address,uint256)"])
+		require.False(t, artifact.NeedsLinking())
+	})
+
+	t.Run("happy path - full output", func(t *testing.T) {
+		// given
+		artifact := &foundry.Artifact{}
+
+		// when
+		err := json.Unmarshal(linkedArtifactJSON, artifact)
+
+		// then
+		require.NoError(t, err)
+		require.True(t, artifact.NeedsLinking())
+		require.Equal(t, common.Address{}.Bytes(), artifact.Bytecode[6:26])
+		require.Equal(t, foundry.LinkReferences{
+			"src/libraries/PairMath.sol": {"PairMath": {{Start: 1, Length: 20}}},
+		}, artifact.DeployedLinkReferences)
+		require.Equal(t, map[string][]foundry.BytecodeRange{
+			"42": {{Start: 22, Length: 32}},
+		}, artifact.ImmutableReferences)
+		require.Equal(t, uint64(3), artifact.ID)
+
+		require.Len(t, artifact.SourceMap, 6)
+		require.Equal(t, foundry.SourceMapEntry{
+			Start:         -1,
+			Length:        -1,
+			File:          -1,
+			Jump:          foundry.JumpOutOf,
+			ModifierDepth: 1,
+		}, artifact.DeployedSourceMap[4])
+
+		slot := artifact.StorageLayout.Slot("reserves")
+		require.NotNil(t, slot)
+		require.Equal(t, "0", slot.Slot)
+		require.Equal(t, "t_uint256", artifact.StorageLayout.Types[slot.Type].Value)
+		require.Nil(t, artifact.StorageLayout.Slot("owner"))
+
+		require.Equal(t, "0.8.33+commit.64118f21", artifact.Metadata.Compiler.Version)
+		require.Equal(t, "prague", artifact.Metadata.Settings.EVMVersion)
+		require.Equal(t, "Pair", artifact.Metadata.Settings.CompilationTarget["src/Factory.sol"])
+		require.Equal(t, "MIT", artifact.Metadata.Sources["src/Factory.sol"].License)
+	})
+
+	t.Run("error - invalid bytecode", func(t *testing.T) {
+		// given
+		data := []byte(`{"abi": [], "bytecode": {"object": "0xzz"}, "deployedBytecode": {"object": "0x"}}`)
+		artifact := &foundry.Artifact{}
+
+		// when
+		err := json.Unmarshal(data, artifact)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifact)
+	})
+
+	t.Run("error - invalid source map", func(t *testing.T) {
+		// given
+		data := []byte(`{"abi": [], "bytecode": {"object": "0x", "sourceMap": "1:x"}, "deployedBytecode": {"object": "0x"}}`)
+		artifact := &foundry.Artifact{}
+
+		// when
+		err := json.Unmarshal(data, artifact)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifact)
+		require.ErrorIs(t, err, foundry.ErrSourceMap)
+	})
+}
+
+func TestArtifact_Link(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		artifact := unmarshalArtifact(t, linkedArtifactJSON)
+		library := common.HexToAddress(libraryAddress)
+
+		// when
+		got, err := artifact.Link(map[string]common.Address{pairMath: library})
+
+		// then
+		require.NoError(t, err)
+		require.False(t, got.NeedsLinking())
+		require.Equal(t, library.Bytes(), got.Bytecode[6:26])
+		require.Equal(t, library.Bytes(), got.DeployedBytecode[1:21])
+		require.True(t, artifact.NeedsLinking())
+		require.Equal(t, common.Address{}.Bytes(), artifact.Bytecode[6:26])
+	})
+
+	t.Run("happy path - library name only", func(t *testing.T) {
+		// given
+		artifact := unmarshalArtifact(t, linkedArtifactJSON)
+		library := common.HexToAddress(libraryAddress)
+
+		// when
+		got, err := artifact.Link(map[string]common.Address{"PairMath": library})
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, library.Bytes(), got.Bytecode[6:26])
+	})
+
+	t.Run("error - missing library", func(t *testing.T) {
+		// given
+		artifact := unmarshalArtifact(t, linkedArtifactJSON)
+
+		// when
+		_, err := artifact.Link(map[string]common.Address{})
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifactUnlinked)
+		require.ErrorContains(t, err, pairMath)
+	})
+}
+
+func TestArtifact_Selector(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		artifact := unmarshalArtifact(t, artifactJSON)
+
+		// when
+		got, err := artifact.Selector("transfer(address,uint256)")
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, artifact.ABI.Methods["transfer"].ID, got)
+	})
+
+	t.Run("error - unknown method", func(t *testing.T) {
+		// given
+		artifact := unmarshalArtifact(t, artifactJSON)
+
+		// when
+		_, err := artifact.Selector("steal()")
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifact)
+	})
+}
+
+func TestLoadArtifact(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		outDir := writeArtifact(t, contractName, artifactJSON)
+
+		// when
+		artifact, err := foundry.LoadArtifact(outDir, contractName)
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, artifact.ABI.Methods, "mint")
+	})
+
+	t.Run("error - artifact not found", func(t *testing.T) {
+		// given
+		outDir := t.TempDir()
+
+		// when
+		_, err := foundry.LoadArtifact(outDir, contractName)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifactNotFound)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("happy path - declared in another file", func(t *testing.T) {
+		// given
+		outDir := writeArtifactIn(t, t.TempDir(), "Factory.sol", "Pair", linkedArtifactJSON)
+
+		// when
+		artifact, err := foundry.LoadArtifact(outDir, "Pair")
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, artifact.ABI.Methods, "token0")
+	})
+
+	t.Run("happy path - qualified name", func(t *testing.T) {
+		// given
+		outDir := writeArtifactIn(t, t.TempDir(), "Factory.sol", "Pair", linkedArtifactJSON)
+		writeArtifactIn(t, outDir, "Router.sol", "Pair", artifactJSON)
+
+		// when
+		artifact, err := foundry.LoadArtifact(outDir, "src/Factory.sol:Pair")
+
+		// then
+		require.NoError(t, err)
+		require.Contains(t, artifact.ABI.Methods, "token0")
+	})
+
+	t.Run("error - ambiguous name", func(t *testing.T) {
+		// given
+		outDir := writeArtifactIn(t, t.TempDir(), "Factory.sol", "Pair", linkedArtifactJSON)
+		writeArtifactIn(t, outDir, "Router.sol", "Pair", artifactJSON)
+
+		// when
+		_, err := foundry.LoadArtifact(outDir, "Pair")
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifactAmbiguous)
+	})
+}
+
+// writeArtifact lays data out as the `forge build` artifact of contractName
+// in a temporary out directory and returns that directory.
+func writeArtifact(t *testing.T, contractName string, data []byte) string {
+	t.Helper()
+	return writeArtifactIn(t, t.TempDir(), contractName+".sol", contractName, data)
+}
+
+// writeArtifactIn lays data out as the artifact of contractName, declared in
+// sourceFile, in outDir and returns outDir.
+func writeArtifactIn(
+	t *testing.T,
+	outDir string,
+	sourceFile string,
+	contractName string,
+	data []byte,
+) string {
+	t.Helper()
+	dir := filepath.Join(outDir, sourceFile)
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, contractName+".json"), data, 0o600))
+	return outDir
+}
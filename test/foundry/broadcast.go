@@ -3,28 +3,143 @@ package foundry
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
 var (
-	ErrBroadcast = errors.New("broadcast")
+	ErrBroadcast        = errors.New("broadcast")
 	ErrContractNotFound = fmt.Errorf("%w: contract not found", ErrBroadcast)
 )
 
+// ContractAddress is a contract created by a broadcast transaction.
+type ContractAddress struct {
+	Name    string
+	Address common.Address
+}
+
+// Return is a value returned by the script's entry point.
+type Return struct {
+	InternalType string `json:"internal_type"`
+	Value        string `json:"value"`
+}
+
+// Broadcast is the run-latest.json file `forge script --broadcast` writes.
+// Path and Multi are only present in files written by older forge versions.
 type Broadcast struct {
-	Transactions []*Transaction `json:"transactions"`
-	Receipts     []*Receipt     `json:"receipts"`
-	Timestamp    uint64         `json:"timestamp"`
-	Chain        uint64         `json:"chain"`
-	Commit       string         `json:"commit"`
+	Transactions []*Transaction     `json:"transactions"`
+	Receipts     []*Receipt         `json:"receipts"`
+	Libraries    []string           `json:"libraries"`
+	Pending      []common.Hash      `json:"pending"`
+	Returns      map[string]*Return `json:"returns"`
+	Timestamp    uint64             `json:"timestamp"`
+	Chain        uint64             `json:"chain"`
+	Multi        bool               `json:"multi,omitempty"`
+	Commit       string             `json:"commit"`
+	Path         string             `json:"path,omitempty"`
 }
 
+// ContractAddresses returns every contract created by the broadcast, in
+// creation order, including those created by factories.
+func (b *Broadcast) ContractAddresses() []ContractAddress {
+	addresses := []ContractAddress{}
+	for _, contract := range b.createdContracts() {
+		addresses = append(addresses, contract.ContractAddress)
+	}
+	return addresses
+}
+
+// GetContractAddress returns the address of the first contract named name,
+// whether a transaction targeted it or a factory created it.
 func (b *Broadcast) GetContractAddress(name string) (*common.Address, error) {
 	for _, tx := range b.Transactions {
 		if tx.ContractName == name {
 			return tx.ContractAddress, nil
 		}
+		for _, additional := range tx.AdditionalContracts {
+			if additional.ContractName == name {
+				return &additional.Address, nil
+			}
+		}
 	}
 	return nil, fmt.Errorf("%w: %s", ErrContractNotFound, name)
 }
+
+// GetContractAddressAt returns the address of the i-th contract created by
+// the broadcast, counting from zero in the order of ContractAddresses.
+func (b *Broadcast) GetContractAddressAt(i int) (*common.Address, error) {
+	contracts := b.createdContracts()
+	if i < 0 || i >= len(contracts) {
+		return nil, fmt.Errorf(
+			"%w: index %d out of %d created contracts",
+			ErrContractNotFound,
+			i,
+			len(contracts),
+		)
+	}
+	return &contracts[i].Address, nil
+}
+
+// GetContractAddresses returns the addresses of every contract named name, in
+// creation order.
+func (b *Broadcast) GetContractAddresses(name string) []common.Address {
+	addresses := []common.Address{}
+	for _, contract := range b.createdContracts() {
+		if contract.Name == name {
+			addresses = append(addresses, contract.Address)
+		}
+	}
+	return addresses
+}
+
+// createdContract is a contract created by the broadcast and the transaction
+// that created it.
+type createdContract struct {
+	ContractAddress
+
+	tx *Transaction
+}
+
+func (b *Broadcast) createdContracts() []createdContract {
+	contracts := []createdContract{}
+	for _, tx := range b.Transactions {
+		if tx.IsCreate() && tx.ContractAddress != nil {
+			contracts = append(contracts, createdContract{
+				ContractAddress: ContractAddress{Name: tx.ContractName, Address: *tx.ContractAddress},
+				tx:              tx,
+			})
+		}
+		for _, additional := range tx.AdditionalContracts {
+			contracts = append(contracts, createdContract{
+				ContractAddress: ContractAddress{Name: additional.ContractName, Address: additional.Address},
+				tx:              tx,
+			})
+		}
+	}
+	return contracts
+}
+
+// libraryAddresses returns the libraries the broadcast deployed, keyed by
+// "<source_file>:<library_name>" as Artifact.Link expects. Forge records
+// each one as "<source_file>:<library_name>:<address>".
+func (b *Broadcast) libraryAddresses() (map[string]common.Address, error) {
+	libraries := make(map[string]common.Address, len(b.Libraries))
+	for _, library := range b.Libraries {
+		i := strings.LastIndex(library, ":")
+		if i < 0 || !common.IsHexAddress(library[i+1:]) {
+			return nil, fmt.Errorf("%w: invalid library %q", ErrBroadcast, library)
+		}
+		libraries[library[:i]] = common.HexToAddress(library[i+1:])
+	}
+	return libraries, nil
+}
+
+func (b *Broadcast) receipt(hash common.Hash) *Receipt {
+	for _, receipt := range b.Receipts {
+		if receipt.TransactionHash == hash {
+			return receipt
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 	"github.com/tahardi/bearchain/test/foundry"
 )
@@ -18,6 +19,59 @@ const (
 //go:embed testdata/broadcast.json
 var broadcastJSON []byte
 
+//go:embed testdata/broadcast-factory.json
+var factoryBroadcastJSON []byte
+
+const (
+	factoryAddress = "0xe7f1725e7734ce288f8367e1bb143e90bb3f0512"
+	firstPair      = "0x61c36a8d610163660e21a8b7359e1cac0c9133e1"
+	secondPair     = "0x23db4a08f2272df049a4932a4cc3a6dc1002b33e"
+)
+
+func TestBroadcast_ContractAddresses(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		token := common.HexToAddress("0x01")
+		factory := common.HexToAddress("0x02")
+		pair := common.HexToAddress("0x03")
+		broadcast := &foundry.Broadcast{
+			Transactions: []*foundry.Transaction{
+				{TransactionType: foundry.TransactionTypeCreate, ContractName: "Token", ContractAddress: &token},
+				{TransactionType: foundry.TransactionTypeCall, ContractName: "Token", ContractAddress: &token},
+				{TransactionType: foundry.TransactionTypeCreate2, ContractName: "Factory", ContractAddress: &factory},
+				{TransactionType: foundry.TransactionTypeCreate, ContractAddress: &pair},
+			},
+		}
+		want := []foundry.ContractAddress{
+			{Name: "Token", Address: token},
+			{Name: "Factory", Address: factory},
+			{Name: "", Address: pair},
+		}
+
+		// when
+		got := broadcast.ContractAddresses()
+
+		// then
+		require.Equal(t, want, got)
+	})
+
+	t.Run("happy path - additional contracts", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		want := []foundry.ContractAddress{
+			{Name: "PairFactory", Address: common.HexToAddress(factoryAddress)},
+			{Name: "Pair", Address: common.HexToAddress(firstPair)},
+			{Name: "Pair", Address: common.HexToAddress(secondPair)},
+		}
+
+		// when
+		got := broadcast.ContractAddresses()
+
+		// then
+		require.Equal(t, want, got)
+	})
+}
+
 func TestBroadcast_GetContractAddress(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
@@ -32,6 +86,18 @@ func TestBroadcast_GetContractAddress(t *testing.T) {
 		require.Equal(t, contractAddress, strings.ToLower(got.Hex()))
 	})
 
+	t.Run("happy path - created by factory", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+
+		// when
+		got, err := broadcast.GetContractAddress("Pair")
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(firstPair), *got)
+	})
+
 	t.Run("error - contract not found", func(t *testing.T) {
 		// given
 		broadcast := &foundry.Broadcast{}
@@ -45,6 +111,59 @@ func TestBroadcast_GetContractAddress(t *testing.T) {
 	})
 }
 
+func TestBroadcast_GetContractAddressAt(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+
+		// when
+		got, err := broadcast.GetContractAddressAt(2)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(secondPair), *got)
+	})
+
+	t.Run("error - out of range", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+
+		// when
+		_, err := broadcast.GetContractAddressAt(3)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrContractNotFound)
+	})
+}
+
+func TestBroadcast_GetContractAddresses(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		want := []common.Address{
+			common.HexToAddress(firstPair),
+			common.HexToAddress(secondPair),
+		}
+
+		// when
+		got := broadcast.GetContractAddresses("Pair")
+
+		// then
+		require.Equal(t, want, got)
+	})
+
+	t.Run("happy path - none", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+
+		// when
+		got := broadcast.GetContractAddresses("HelloWorld")
+
+		// then
+		require.Empty(t, got)
+	})
+}
+
 func TestBroadcast_JSON(t *testing.T) {
 	t.Run("happy path - round trip", func(t *testing.T) {
 		// given
@@ -61,4 +180,32 @@ func TestBroadcast_JSON(t *testing.T) {
 		require.NoError(t, err)
 		require.JSONEq(t, string(want), string(got))
 	})
+
+	t.Run("happy path - round trip full schema", func(t *testing.T) {
+		// given
+		want := factoryBroadcastJSON
+
+		// when
+		broadcast := unmarshalBroadcast(t, want)
+		got, err := json.Marshal(broadcast)
+
+		// then
+		require.NoError(t, err)
+		require.JSONEq(t, string(want), string(got))
+		require.Equal(t, []string{
+			"src/libraries/PairMath.sol:PairMath:0x5fbdb2315678afecb367f032d93f642f64180aa3",
+		}, broadcast.Libraries)
+		require.Len(t, broadcast.Pending, 1)
+		require.Equal(t, broadcast.Transactions[2].Hash, broadcast.Pending[0])
+		require.Equal(t, firstPair, broadcast.Returns["pair"].Value)
+		require.Equal(t, "createPair(address,address)", *broadcast.Transactions[1].Function)
+		require.Len(t, broadcast.Transactions[1].Arguments, 2)
+	})
+}
+
+func unmarshalBroadcast(t *testing.T, data []byte) *foundry.Broadcast {
+	t.Helper()
+	broadcast := &foundry.Broadcast{}
+	require.NoError(t, json.Unmarshal(data, broadcast))
+	return broadcast
 }
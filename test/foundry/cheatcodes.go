@@ -0,0 +1,243 @@
+package foundry
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	DropTransactionMethod          = "anvil_dropTransaction"
+	DumpStateMethod                = "anvil_dumpState"
+	ImpersonateAccountMethod       = "anvil_impersonateAccount"
+	LoadStateMethod                = "anvil_loadState"
+	MineMethod                     = "anvil_mine"
+	ResetMethod                    = "anvil_reset"
+	SetAutomineMethod              = "anvil_setAutomine"
+	SetBalanceMethod               = "anvil_setBalance"
+	SetCodeMethod                  = "anvil_setCode"
+	SetIntervalMiningMethod        = "anvil_setIntervalMining"
+	SetNonceMethod                 = "anvil_setNonce"
+	SetStorageAtMethod             = "anvil_setStorageAt"
+	StopImpersonatingAccountMethod = "anvil_stopImpersonatingAccount"
+
+	EVMIncreaseTimeMethod          = "evm_increaseTime"
+	EVMRevertMethod                = "evm_revert"
+	EVMSetNextBlockTimestampMethod = "evm_setNextBlockTimestamp"
+	EVMSnapshotMethod              = "evm_snapshot"
+
+	StateFileMode = 0o600
+)
+
+var (
+	ErrLoadState           = fmt.Errorf("%w: loading state", ErrAnvil)
+	ErrNegativeDuration    = fmt.Errorf("%w: negative duration", ErrAnvil)
+	ErrSnapshotNotFound    = fmt.Errorf("%w: snapshot not found", ErrAnvil)
+	ErrTransactionNotFound = fmt.Errorf("%w: transaction not found", ErrAnvil)
+)
+
+// DropTransaction removes a pending transaction from the mempool.
+func (a *Anvil) DropTransaction(ctx context.Context, hash common.Hash) error {
+	var dropped *common.Hash
+	err := a.call(ctx, &dropped, DropTransactionMethod, hash)
+	if err != nil {
+		return err
+	}
+	if dropped == nil {
+		return fmt.Errorf("%w: %s", ErrTransactionNotFound, hash)
+	}
+	return nil
+}
+
+// DumpState writes the current chain state to path, in the JSON format read
+// by LoadState and WithLoadState.
+func (a *Anvil) DumpState(ctx context.Context, path string) error {
+	state := hexutil.Bytes{}
+	err := a.call(ctx, &state, DumpStateMethod)
+	if err != nil {
+		return err
+	}
+
+	// Anvil gzips the state it returns over RPC but not the one it writes
+	// with --dump-state, which is the format we keep on disk.
+	data, err := gunzip(state)
+	if err != nil {
+		return fmt.Errorf("%w: decompressing state: %w", ErrAnvil, err)
+	}
+
+	err = os.WriteFile(path, data, StateFileMode)
+	if err != nil {
+		return fmt.Errorf("%w: writing state: %w", ErrAnvil, err)
+	}
+	return nil
+}
+
+// ImpersonateAccount lets transactions sent with `eth_sendTransaction` from
+// address skip signature verification, until StopImpersonatingAccount.
+func (a *Anvil) ImpersonateAccount(ctx context.Context, address common.Address) error {
+	return a.call(ctx, nil, ImpersonateAccountMethod, address)
+}
+
+// IncreaseTime moves the clock forward for every block mined afterwards.
+// Anvil only tracks whole seconds.
+func (a *Anvil) IncreaseTime(ctx context.Context, d time.Duration) error {
+	secs, err := seconds(d)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, nil, EVMIncreaseTimeMethod, hexutil.Uint64(secs))
+}
+
+// LoadState merges the chain state saved at path into the current state.
+func (a *Anvil) LoadState(ctx context.Context, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: reading state: %w", ErrAnvil, err)
+	}
+
+	loaded := false
+	err = a.call(ctx, &loaded, LoadStateMethod, hexutil.Bytes(data))
+	if err != nil {
+		return err
+	}
+	if !loaded {
+		return fmt.Errorf("%w: %s", ErrLoadState, path)
+	}
+	return nil
+}
+
+// Mine mines the given number of blocks, spacing their timestamps interval
+// apart. A zero interval lets Anvil pick the timestamps.
+func (a *Anvil) Mine(ctx context.Context, blocks uint64, interval time.Duration) error {
+	secs, err := seconds(interval)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, nil, MineMethod, hexutil.Uint64(blocks), hexutil.Uint64(secs))
+}
+
+// Reset discards all chain state and starts again from genesis.
+func (a *Anvil) Reset(ctx context.Context) error {
+	return a.call(ctx, nil, ResetMethod)
+}
+
+// Revert restores the chain state recorded by Snapshot. Anvil discards the
+// snapshot, and every snapshot taken after it, once it has been reverted to.
+func (a *Anvil) Revert(ctx context.Context, id *big.Int) error {
+	reverted := false
+	err := a.call(ctx, &reverted, EVMRevertMethod, (*hexutil.Big)(id))
+	if err != nil {
+		return err
+	}
+	if !reverted {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, id)
+	}
+	return nil
+}
+
+// SetAutomine toggles mining a block for every transaction.
+func (a *Anvil) SetAutomine(ctx context.Context, enabled bool) error {
+	return a.call(ctx, nil, SetAutomineMethod, enabled)
+}
+
+// SetBalance overwrites the balance, in wei, of address.
+func (a *Anvil) SetBalance(ctx context.Context, address common.Address, balance *big.Int) error {
+	return a.call(ctx, nil, SetBalanceMethod, address, (*hexutil.Big)(balance))
+}
+
+// SetCode overwrites the runtime bytecode at address.
+func (a *Anvil) SetCode(ctx context.Context, address common.Address, code []byte) error {
+	return a.call(ctx, nil, SetCodeMethod, address, hexutil.Bytes(code))
+}
+
+// SetIntervalMining mines a block every interval. A zero interval disables
+// interval mining.
+func (a *Anvil) SetIntervalMining(ctx context.Context, interval time.Duration) error {
+	secs, err := seconds(interval)
+	if err != nil {
+		return err
+	}
+	return a.call(ctx, nil, SetIntervalMiningMethod, hexutil.Uint64(secs))
+}
+
+// SetNextBlockTimestamp fixes the timestamp, in seconds, of the next block.
+// It must be later than the latest block's timestamp.
+func (a *Anvil) SetNextBlockTimestamp(ctx context.Context, timestamp uint64) error {
+	return a.call(ctx, nil, EVMSetNextBlockTimestampMethod, hexutil.Uint64(timestamp))
+}
+
+// SetNonce overwrites the nonce of address.
+func (a *Anvil) SetNonce(ctx context.Context, address common.Address, nonce uint64) error {
+	return a.call(ctx, nil, SetNonceMethod, address, hexutil.Uint64(nonce))
+}
+
+// SetStorageAt overwrites a single storage slot of the contract at address.
+func (a *Anvil) SetStorageAt(
+	ctx context.Context,
+	address common.Address,
+	slot common.Hash,
+	value common.Hash,
+) error {
+	return a.call(ctx, nil, SetStorageAtMethod, address, slot, value)
+}
+
+// Snapshot records the current chain state and returns an ID that Revert can
+// later restore.
+func (a *Anvil) Snapshot(ctx context.Context) (*big.Int, error) {
+	id := &hexutil.Big{}
+	err := a.call(ctx, id, EVMSnapshotMethod)
+	if err != nil {
+		return nil, err
+	}
+	return id.ToInt(), nil
+}
+
+// StopImpersonatingAccount undoes ImpersonateAccount.
+func (a *Anvil) StopImpersonatingAccount(ctx context.Context, address common.Address) error {
+	return a.call(ctx, nil, StopImpersonatingAccountMethod, address)
+}
+
+func (a *Anvil) call(ctx context.Context, result any, method string, args ...any) error {
+	client, err := a.Client()
+	if err != nil {
+		return err
+	}
+
+	err = client.Client().CallContext(ctx, result, method, args...)
+	if err != nil {
+		return fmt.Errorf("%w: calling %s: %w", ErrAnvil, method, err)
+	}
+	return nil
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return data, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// seconds truncates d to whole seconds, the only resolution Anvil's clock
+// has. Anvil cannot move its clock backwards, so negative durations are an
+// error.
+func seconds(d time.Duration) (uint64, error) {
+	secs := int64(d / time.Second)
+	if d < 0 || secs < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrNegativeDuration, d)
+	}
+	return uint64(secs), nil
+}
@@ -0,0 +1,435 @@
+package foundry_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+func TestAnvil_SetAutomine(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetAutomineMethod: nil,
+		})
+
+		// when
+		err := anvil.SetAutomine(t.Context(), false)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.SetAutomineMethod), "false")
+	})
+}
+
+func TestAnvil_SetBalance(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetBalanceMethod: nil,
+		})
+		balance, ok := new(big.Int).SetString("100000000000000000000", 10)
+		require.True(t, ok)
+
+		// when
+		err := anvil.SetBalance(t.Context(), common.HexToAddress(cheatAddress), balance)
+
+		// then
+		require.NoError(t, err)
+		requireParams(
+			t,
+			fake.lastCall(t, foundry.SetBalanceMethod),
+			strconv.Quote(cheatAddress),
+			`"0x56bc75e2d63100000"`,
+		)
+	})
+}
+
+func TestAnvil_SetCode(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetCodeMethod: nil,
+		})
+
+		// when
+		err := anvil.SetCode(t.Context(), common.HexToAddress(cheatAddress), []byte{0x60, 0x00})
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.SetCodeMethod), strconv.Quote(cheatAddress), `"0x6000"`)
+	})
+}
+
+func TestAnvil_SetIntervalMining(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetIntervalMiningMethod: nil,
+		})
+
+		// when
+		err := anvil.SetIntervalMining(t.Context(), 2*time.Second)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.SetIntervalMiningMethod), `"0x2"`)
+	})
+
+	t.Run("error - negative interval", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetIntervalMiningMethod: nil,
+		})
+
+		// when
+		err := anvil.SetIntervalMining(t.Context(), -time.Second)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNegativeDuration)
+	})
+}
+
+func TestAnvil_SetNextBlockTimestamp(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.EVMSetNextBlockTimestampMethod: nil,
+		})
+
+		// when
+		err := anvil.SetNextBlockTimestamp(t.Context(), foundry.GenesisTimestamp+60)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.EVMSetNextBlockTimestampMethod), `"0x6970fb5a"`)
+	})
+}
+
+func TestAnvil_SetNonce(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetNonceMethod: nil,
+		})
+
+		// when
+		err := anvil.SetNonce(t.Context(), common.HexToAddress(cheatAddress), 42)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.SetNonceMethod), strconv.Quote(cheatAddress), `"0x2a"`)
+	})
+}
+
+func TestAnvil_SetStorageAt(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.SetStorageAtMethod: true,
+		})
+		slot := common.BigToHash(big.NewInt(5))
+		value := common.HexToHash(cheatHash)
+
+		// when
+		err := anvil.SetStorageAt(t.Context(), common.HexToAddress(cheatAddress), slot, value)
+
+		// then
+		require.NoError(t, err)
+		requireParams(
+			t,
+			fake.lastCall(t, foundry.SetStorageAtMethod),
+			strconv.Quote(cheatAddress),
+			strconv.Quote(slot.Hex()),
+			strconv.Quote(cheatHash),
+		)
+	})
+}
+
+func TestAnvil_Snapshot(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.EVMSnapshotMethod: "0x2",
+		})
+
+		// when
+		got, err := anvil.Snapshot(t.Context())
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, 0, got.Cmp(big.NewInt(2)))
+	})
+}
+
+const (
+	cheatAddress = "0x70997970c51812dc3a010c7d01b50e0d17dc79c8"
+	cheatHash    = "0x8eb0f4bc5c6341130ff9535e59d4870fffe36c603fbd449c78cadfc5cef42025"
+)
+
+func TestAnvil_DropTransaction(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.DropTransactionMethod: cheatHash,
+		})
+
+		// when
+		err := anvil.DropTransaction(t.Context(), common.HexToHash(cheatHash))
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.DropTransactionMethod), strconv.Quote(cheatHash))
+	})
+
+	t.Run("error - transaction not found", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.DropTransactionMethod: nil,
+		})
+
+		// when
+		err := anvil.DropTransaction(t.Context(), common.HexToHash(cheatHash))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrTransactionNotFound)
+	})
+}
+
+func TestAnvil_DumpState(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		want := `{"accounts":{}}`
+		compressed := &bytes.Buffer{}
+		writer := gzip.NewWriter(compressed)
+		_, err := writer.Write([]byte(want))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.DumpStateMethod: hexutil.Encode(compressed.Bytes()),
+		})
+		path := filepath.Join(t.TempDir(), "state.json")
+
+		// when
+		err = anvil.DumpState(t.Context(), path)
+
+		// then
+		require.NoError(t, err)
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.JSONEq(t, want, string(got))
+	})
+}
+
+func TestAnvil_ImpersonateAccount(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.ImpersonateAccountMethod:       nil,
+			foundry.StopImpersonatingAccountMethod: nil,
+		})
+		address := common.HexToAddress(cheatAddress)
+
+		// when
+		err := anvil.ImpersonateAccount(t.Context(), address)
+		require.NoError(t, err)
+
+		err = anvil.StopImpersonatingAccount(t.Context(), address)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.ImpersonateAccountMethod), strconv.Quote(cheatAddress))
+		requireParams(t, fake.lastCall(t, foundry.StopImpersonatingAccountMethod), strconv.Quote(cheatAddress))
+	})
+}
+
+func TestAnvil_IncreaseTime(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.EVMIncreaseTimeMethod: 3600,
+		})
+
+		// when
+		err := anvil.IncreaseTime(t.Context(), time.Hour)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.EVMIncreaseTimeMethod), `"0xe10"`)
+	})
+
+	t.Run("error - negative duration", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.EVMIncreaseTimeMethod: 0,
+		})
+
+		// when
+		err := anvil.IncreaseTime(t.Context(), -time.Hour)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNegativeDuration)
+		require.ErrorIs(t, err, foundry.ErrAnvil)
+	})
+}
+
+func TestAnvil_LoadState(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		state := []byte(`{"accounts":{}}`)
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, state, foundry.StateFileMode))
+
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.LoadStateMethod: true,
+		})
+
+		// when
+		err := anvil.LoadState(t.Context(), path)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.LoadStateMethod), strconv.Quote(hexutil.Encode(state)))
+	})
+
+	t.Run("error - not loaded", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{}`), foundry.StateFileMode))
+
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.LoadStateMethod: false,
+		})
+
+		// when
+		err := anvil.LoadState(t.Context(), path)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrLoadState)
+	})
+}
+
+func TestAnvil_Mine(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.MineMethod: nil,
+		})
+
+		// when
+		err := anvil.Mine(t.Context(), 10, 12*time.Second)
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.MineMethod), `"0xa"`, `"0xc"`)
+	})
+
+	t.Run("error - rpc error", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.MineMethod: fakeRPCError{Code: -32603, Message: "internal error"},
+		})
+
+		// when
+		err := anvil.Mine(t.Context(), 1, 0)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvil)
+		require.ErrorContains(t, err, "internal error")
+	})
+
+	t.Run("error - negative interval", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.MineMethod: nil,
+		})
+
+		// when
+		err := anvil.Mine(t.Context(), 1, -12*time.Second)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNegativeDuration)
+	})
+}
+
+func TestAnvil_Reset(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.ResetMethod: nil,
+		})
+
+		// when
+		err := anvil.Reset(t.Context())
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.ResetMethod))
+	})
+}
+
+func TestAnvil_Revert(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		anvil, fake := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.EVMRevertMethod: true,
+		})
+
+		// when
+		err := anvil.Revert(t.Context(), big.NewInt(2))
+
+		// then
+		require.NoError(t, err)
+		requireParams(t, fake.lastCall(t, foundry.EVMRevertMethod), `"0x2"`)
+	})
+
+	t.Run("error - snapshot not found", func(t *testing.T) {
+		// given
+		anvil, _ := startFakeAnvilRPC(t, "exec sleep 30", map[string]any{
+			foundry.EVMRevertMethod: false,
+		})
+
+		// when
+		err := anvil.Revert(t.Context(), big.NewInt(2))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSnapshotNotFound)
+	})
+
+	t.Run("error - not running", func(t *testing.T) {
+		// given
+		anvil, err := foundry.NewAnvil(broadcastDir, scriptDir)
+		require.NoError(t, err)
+
+		// when
+		err = anvil.Revert(t.Context(), big.NewInt(2))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilState)
+	})
+}
+
+func requireParams(t *testing.T, call fakeCall, want ...string) {
+	t.Helper()
+	if len(want) == 0 {
+		require.Empty(t, call.Params)
+		return
+	}
+
+	got := make([]string, len(call.Params))
+	for i, param := range call.Params {
+		got[i] = string(param)
+	}
+	require.Equal(t, want, got)
+}
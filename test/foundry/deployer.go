@@ -0,0 +1,109 @@
+package foundry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrDeployer     = errors.New("deployer")
+	ErrDeployFailed = fmt.Errorf("%w: deployment reverted", ErrDeployer)
+)
+
+// Backend is the chain access a Deployer needs. *ethclient.Client satisfies
+// it.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// DeployedContract is a contract created by a deployment.
+type DeployedContract struct {
+	Name            string
+	Address         common.Address
+	TransactionHash common.Hash
+	Receipt         *types.Receipt
+}
+
+// Deployer deploys contracts straight from their `forge build` artifacts,
+// without going through `forge script`. It neither writes to the source tree
+// nor shares files between deployments, so it is safe to use concurrently.
+type Deployer struct {
+	outDir  string
+	backend Backend
+	chainID *big.Int
+}
+
+func NewDeployer(
+	outDir string,
+	backend Backend,
+	chainID *big.Int,
+) *Deployer {
+	return &Deployer{
+		outDir:  outDir,
+		backend: backend,
+		chainID: chainID,
+	}
+}
+
+// Deploy sends the creation transaction of contractName from the given
+// account, ABI-encoding args as constructor arguments, and waits for it to be
+// mined.
+func (d *Deployer) Deploy(
+	ctx context.Context,
+	contractName string,
+	from Signer,
+	args ...any,
+) (*DeployedContract, error) {
+	artifact, err := LoadArtifact(d.outDir, contractName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: loading artifact: %w", ErrDeployer, err)
+	}
+	return d.DeployArtifact(ctx, contractName, artifact, from, args...)
+}
+
+// DeployArtifact is Deploy for an artifact that has already been loaded.
+// Artifacts that reference libraries must be linked first with Artifact.Link.
+func (d *Deployer) DeployArtifact(
+	ctx context.Context,
+	contractName string,
+	artifact *Artifact,
+	from Signer,
+	args ...any,
+) (*DeployedContract, error) {
+	if artifact.NeedsLinking() {
+		return nil, fmt.Errorf("%w: %s: %w", ErrDeployer, contractName, ErrArtifactUnlinked)
+	}
+
+	opts, err := NewTransactor(from, d.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: creating transactor: %w", ErrDeployer, err)
+	}
+	opts.Context = ctx
+
+	address, tx, _, err := bind.DeployContract(opts, artifact.ABI, artifact.Bytecode, d.backend, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sending creation transaction: %w", ErrDeployer, err)
+	}
+
+	receipt, err := bind.WaitMined(ctx, d.backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: waiting for receipt: %w", ErrDeployer, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%w: %s: %s", ErrDeployFailed, contractName, tx.Hash())
+	}
+
+	return &DeployedContract{
+		Name:            contractName,
+		Address:         address,
+		TransactionHash: tx.Hash(),
+		Receipt:         receipt,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package foundry_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/mocks"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+var errInsufficientFunds = errors.New("insufficient funds")
+
+func TestDeployer_Deploy(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+		want := crypto.CreateAddress(owner.Address(), 0)
+
+		backend := mocks.NewBackend(t)
+		expectCreationTransaction(backend)
+
+		var sent *types.Transaction
+		backend.EXPECT().
+			SendTransaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, tx *types.Transaction) error {
+				sent = tx
+				return nil
+			})
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, mock.Anything).
+			Return(&types.Receipt{Status: types.ReceiptStatusSuccessful, ContractAddress: want}, nil)
+
+		outDir := writeArtifact(t, contractName, artifactJSON)
+		deployer := foundry.NewDeployer(outDir, backend, big.NewInt(foundry.ChainID))
+
+		// when
+		got, err := deployer.Deploy(t.Context(), contractName, owner)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, contractName, got.Name)
+		require.Equal(t, want, got.Address)
+		require.Equal(t, sent.Hash(), got.TransactionHash)
+		require.Nil(t, sent.To())
+		require.Equal(t, uint64(0), sent.Nonce())
+
+		artifact, err := foundry.LoadArtifact(outDir, contractName)
+		require.NoError(t, err)
+		require.Equal(t, artifact.Bytecode, sent.Data())
+	})
+
+	t.Run("error - deployment reverted", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+
+		backend := mocks.NewBackend(t)
+		expectCreationTransaction(backend)
+		backend.EXPECT().SendTransaction(mock.Anything, mock.Anything).Return(nil)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, mock.Anything).
+			Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil)
+
+		outDir := writeArtifact(t, contractName, artifactJSON)
+		deployer := foundry.NewDeployer(outDir, backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := deployer.Deploy(t.Context(), contractName, owner)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDeployFailed)
+	})
+
+	t.Run("error - send fails", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+
+		backend := mocks.NewBackend(t)
+		expectCreationTransaction(backend)
+		backend.EXPECT().
+			SendTransaction(mock.Anything, mock.Anything).
+			Return(errInsufficientFunds)
+
+		outDir := writeArtifact(t, contractName, artifactJSON)
+		deployer := foundry.NewDeployer(outDir, backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := deployer.Deploy(t.Context(), contractName, owner)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDeployer)
+		require.ErrorIs(t, err, errInsufficientFunds)
+	})
+
+	t.Run("error - unexpected constructor arguments", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+
+		outDir := writeArtifact(t, contractName, artifactJSON)
+		deployer := foundry.NewDeployer(outDir, backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := deployer.Deploy(t.Context(), contractName, owner, big.NewInt(1))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDeployer)
+	})
+
+	t.Run("error - artifact not found", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		deployer := foundry.NewDeployer(t.TempDir(), backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := deployer.Deploy(t.Context(), contractName, owner)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifact)
+	})
+
+	t.Run("error - unlinked library", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		outDir := writeArtifactIn(t, t.TempDir(), "Factory.sol", "Pair", linkedArtifactJSON)
+		deployer := foundry.NewDeployer(outDir, backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := deployer.Deploy(t.Context(), "Pair", owner)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrDeployer)
+		require.ErrorIs(t, err, foundry.ErrArtifactUnlinked)
+	})
+}
+
+// expectCreationTransaction stubs the calls bind makes to price and sign an
+// EIP-1559 creation transaction from a fresh account.
+func expectCreationTransaction(backend *mocks.Backend) {
+	backend.EXPECT().
+		HeaderByNumber(mock.Anything, mock.Anything).
+		Return(&types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(foundry.BaseFee)}, nil)
+	backend.EXPECT().SuggestGasTipCap(mock.Anything).Return(big.NewInt(1), nil)
+	backend.EXPECT().PendingNonceAt(mock.Anything, mock.Anything).Return(0, nil)
+	backend.EXPECT().EstimateGas(mock.Anything, mock.Anything).Return(2_000_000, nil)
+}
+
+func requireAccount(t *testing.T, i int) *foundry.Account {
+	t.Helper()
+	accounts, err := foundry.NewDefaultAnvilAccounts()
+	require.NoError(t, err)
+	return accounts[i]
+}
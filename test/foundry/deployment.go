@@ -0,0 +1,83 @@
+package foundry
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Deployment is what a `forge script` broadcast did to the chain: the
+// contracts it created and what it cost.
+//
+//nolint:tagliatelle
+type Deployment struct {
+	Contracts         []*DeploymentContract `json:"contracts"`
+	GasUsed           uint64                `json:"gasUsed"`
+	EffectiveGasPrice *big.Int              `json:"effectiveGasPrice"`
+	Cost              *big.Int              `json:"cost"`
+	Chain             uint64                `json:"chain"`
+	Commit            string                `json:"commit"`
+}
+
+// DeploymentContract is a contract created by a deployment, with the
+// transaction that created it, directly or through a factory, and its
+// receipt. Receipt is nil if the transaction is still pending.
+type DeploymentContract struct {
+	Name        string         `json:"name"`
+	Address     common.Address `json:"address"`
+	Transaction *Transaction   `json:"transaction"`
+	Receipt     *Receipt       `json:"receipt"`
+}
+
+// NewDeployment summarizes a broadcast. GasUsed and Cost add up every
+// receipt, and EffectiveGasPrice is the gas-weighted average of their
+// prices.
+func NewDeployment(broadcast *Broadcast) *Deployment {
+	deployment := &Deployment{
+		Contracts:         []*DeploymentContract{},
+		EffectiveGasPrice: new(big.Int),
+		Cost:              new(big.Int),
+		Chain:             broadcast.Chain,
+		Commit:            broadcast.Commit,
+	}
+
+	for _, receipt := range broadcast.Receipts {
+		deployment.GasUsed += receipt.GasUsed
+		if receipt.EffectiveGasPrice == nil {
+			continue
+		}
+		cost := new(big.Int).SetUint64(receipt.GasUsed)
+		cost.Mul(cost, receipt.EffectiveGasPrice)
+		deployment.Cost.Add(deployment.Cost, cost)
+	}
+	if deployment.GasUsed > 0 {
+		deployment.EffectiveGasPrice.Div(deployment.Cost, new(big.Int).SetUint64(deployment.GasUsed))
+	}
+
+	for _, contract := range broadcast.createdContracts() {
+		deployment.Contracts = append(deployment.Contracts, &DeploymentContract{
+			Name:        contract.Name,
+			Address:     contract.Address,
+			Transaction: contract.tx,
+			Receipt:     broadcast.receipt(contract.tx.Hash),
+		})
+	}
+	return deployment
+}
+
+// Contract returns the first contract named name, or nil.
+func (d *Deployment) Contract(name string) *DeploymentContract {
+	for _, contract := range d.Contracts {
+		if contract.Name == name {
+			return contract
+		}
+	}
+	return nil
+}
+
+func (c *DeploymentContract) gasUsed() uint64 {
+	if c.Receipt == nil {
+		return 0
+	}
+	return c.Receipt.GasUsed
+}
@@ -0,0 +1,100 @@
+package foundry_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+func TestNewDeployment(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		broadcast := &foundry.Broadcast{}
+		require.NoError(t, json.Unmarshal(broadcastJSON, broadcast))
+
+		// when
+		got := foundry.NewDeployment(broadcast)
+
+		// then
+		require.Equal(t, uint64(31337), got.Chain)
+		require.Equal(t, "219c667", got.Commit)
+		require.Equal(t, uint64(0x1582f4), got.GasUsed)
+		require.Equal(t, big.NewInt(0x3b9aca01), got.EffectiveGasPrice)
+		require.Equal(t, big.NewInt(0x1582f4*0x3b9aca01), got.Cost)
+
+		require.Len(t, got.Contracts, 1)
+		contract := got.Contracts[0]
+		require.Equal(t, contractName, contract.Name)
+		require.Equal(t, common.HexToAddress(contractAddress), contract.Address)
+		require.Equal(t, broadcast.Transactions[0], contract.Transaction)
+		require.Equal(t, broadcast.Receipts[0], contract.Receipt)
+		require.Equal(t, uint64(1), contract.Receipt.BlockNumber)
+	})
+
+	t.Run("happy path - pending and averaged", func(t *testing.T) {
+		// given
+		first := common.HexToAddress("0x01")
+		second := common.HexToAddress("0x02")
+		broadcast := &foundry.Broadcast{
+			Transactions: []*foundry.Transaction{
+				{Hash: common.Hash{1}, TransactionType: foundry.TransactionTypeCreate, ContractName: "A", ContractAddress: &first},
+				{Hash: common.Hash{2}, TransactionType: foundry.TransactionTypeCall, ContractName: "A", ContractAddress: &first},
+				{Hash: common.Hash{3}, TransactionType: foundry.TransactionTypeCreate, ContractName: "B", ContractAddress: &second},
+			},
+			Receipts: []*foundry.Receipt{
+				{TransactionHash: common.Hash{1}, GasUsed: 100, EffectiveGasPrice: big.NewInt(10)},
+				{TransactionHash: common.Hash{2}, GasUsed: 300, EffectiveGasPrice: big.NewInt(30)},
+			},
+		}
+
+		// when
+		got := foundry.NewDeployment(broadcast)
+
+		// then
+		require.Equal(t, uint64(400), got.GasUsed)
+		require.Equal(t, big.NewInt(10_000), got.Cost)
+		require.Equal(t, big.NewInt(25), got.EffectiveGasPrice)
+		require.Len(t, got.Contracts, 2)
+		require.Equal(t, broadcast.Receipts[0], got.Contract("A").Receipt)
+		require.Nil(t, got.Contract("B").Receipt)
+		require.Nil(t, got.Contract("C"))
+	})
+
+	t.Run("happy path - additional contracts", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+
+		// when
+		got := foundry.NewDeployment(broadcast)
+
+		// then
+		require.Len(t, got.Contracts, 3)
+		pair := got.Contract("Pair")
+		require.Equal(t, common.HexToAddress(firstPair), pair.Address)
+		require.Equal(t, broadcast.Transactions[1], pair.Transaction)
+		require.Equal(t, broadcast.Receipts[1], pair.Receipt)
+		require.Nil(t, got.Contracts[2].Receipt)
+	})
+
+	t.Run("happy path - json", func(t *testing.T) {
+		// given
+		broadcast := &foundry.Broadcast{}
+		require.NoError(t, json.Unmarshal(broadcastJSON, broadcast))
+		want := foundry.NewDeployment(broadcast)
+
+		// when
+		data, err := json.Marshal(want)
+		require.NoError(t, err)
+
+		got := &foundry.Deployment{}
+		err = json.Unmarshal(data, got)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,58 @@
+package foundry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EventSignatureMismatchMessage is how abigen parsers reject a log of
+// another event.
+const EventSignatureMismatchMessage = "event signature mismatch"
+
+var (
+	ErrEvent = errors.New("event")
+)
+
+// EventParser decodes a log into an event. It is the Parse<Event> method of
+// an abigen filterer, such as BearCoinFilterer.ParseTransfer, and fails for
+// logs of other events.
+type EventParser[T any] func(log types.Log) (*T, error)
+
+// Events returns the events parse decodes from the logs address emitted in
+// receipt, in log order.
+//
+// Logs of the contract's other events, anonymous ones included, are skipped.
+// A log that has the event's signature but fails to parse, for instance
+// because its topics or data are malformed, is an ErrEvent.
+func Events[T any](
+	receipt *types.Receipt,
+	address common.Address,
+	parse EventParser[T],
+) ([]*T, error) {
+	events := []*T{}
+	for _, log := range receipt.Logs {
+		// Anonymous events have no signature topic and never parse.
+		if log.Address != address || len(log.Topics) == 0 {
+			continue
+		}
+		event, err := parse(*log)
+		switch {
+		case IsEventSignatureMismatch(err):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("%w: parsing log %d of %s: %w", ErrEvent, log.Index, address.Hex(), err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// IsEventSignatureMismatch reports whether err is a parser rejecting a log
+// of another event. abigen parsers only expose it through the message.
+func IsEventSignatureMismatch(err error) bool {
+	return err != nil && strings.Contains(err.Error(), EventSignatureMismatchMessage)
+}
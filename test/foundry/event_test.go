@@ -0,0 +1,112 @@
+package foundry_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+var (
+	errSignatureMismatch = errors.New("event signature mismatch")
+	errMalformedLog      = errors.New("abi: improperly formatted output")
+
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+)
+
+// transferEvent mimics the events abigen generates.
+type transferEvent struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log
+}
+
+func TestEvents(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		token, other := common.Address{0x01}, common.Address{0x02}
+		from, to := common.Address{0x0a}, common.Address{0x0b}
+		receipt := &types.Receipt{Logs: []*types.Log{
+			newTransferLog(token, from, to, 1),
+			{Address: token, Topics: []common.Hash{approvalTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())}},
+			newTransferLog(other, from, to, 2),
+			newTransferLog(token, to, from, 3),
+		}}
+
+		// when
+		got, err := foundry.Events(receipt, token, parseTransfer)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, from, got[0].From)
+		require.Equal(t, to, got[0].To)
+		require.Equal(t, big.NewInt(1), got[0].Value)
+		require.Equal(t, to, got[1].From)
+		require.Equal(t, big.NewInt(3), got[1].Value)
+	})
+
+	t.Run("happy path - no logs", func(t *testing.T) {
+		// given
+		receipt := &types.Receipt{}
+
+		// when
+		got, err := foundry.Events(receipt, common.Address{0x01}, parseTransfer)
+
+		// then
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("error - malformed log", func(t *testing.T) {
+		// given
+		token := common.Address{0x01}
+		from, to := common.Address{0x0a}, common.Address{0x0b}
+		malformed := newTransferLog(token, from, to, 2)
+		malformed.Topics = malformed.Topics[:2]
+		receipt := &types.Receipt{Logs: []*types.Log{newTransferLog(token, from, to, 1), malformed}}
+
+		// when
+		_, err := foundry.Events(receipt, token, parseTransfer)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrEvent)
+		require.ErrorIs(t, err, errMalformedLog)
+	})
+}
+
+func TestIsEventSignatureMismatch(t *testing.T) {
+	require.True(t, foundry.IsEventSignatureMismatch(errSignatureMismatch))
+	require.False(t, foundry.IsEventSignatureMismatch(errMalformedLog))
+	require.False(t, foundry.IsEventSignatureMismatch(nil))
+}
+
+func newTransferLog(address common.Address, from common.Address, to common.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: address,
+		Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(big.NewInt(value)).Bytes(),
+	}
+}
+
+func parseTransfer(log types.Log) (*transferEvent, error) {
+	if len(log.Topics) == 0 || log.Topics[0] != transferTopic {
+		return nil, errSignatureMismatch
+	}
+	if len(log.Topics) != 3 {
+		return nil, errMalformedLog
+	}
+	return &transferEvent{
+		From:  common.BytesToAddress(log.Topics[1].Bytes()),
+		To:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Value: new(big.Int).SetBytes(log.Data),
+		Raw:   log,
+	}, nil
+}
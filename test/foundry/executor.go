@@ -0,0 +1,212 @@
+package foundry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const ExecuteTimeout = 30 * time.Second
+
+var (
+	ErrExecutor            = errors.New("executor")
+	ErrExecuteTimeout      = fmt.Errorf("%w: timed out waiting for receipt", ErrExecutor)
+	ErrTransactionReverted = fmt.Errorf("%w: transaction reverted", ErrExecutor)
+)
+
+// ExecutorConfig holds the settings of an Executor.
+type ExecutorConfig struct {
+	// Timeout bounds sending a transaction and waiting for its receipt.
+	Timeout time.Duration
+	// ErrorABIs are the contract ABIs whose custom errors reverts are decoded
+	// into, on top of Error, Panic and IERC20Errors.
+	ErrorABIs []*abi.ABI
+	// Nonces hands out nonces when set, so transactions from one account can
+	// be executed concurrently. Otherwise the node's pending nonce is used.
+	Nonces *NonceManager
+}
+
+func DefaultExecutorConfig() *ExecutorConfig {
+	return &ExecutorConfig{
+		Timeout:   ExecuteTimeout,
+		ErrorABIs: nil,
+		Nonces:    nil,
+	}
+}
+
+// ExecutorOption overrides a field of the ExecutorConfig used by NewExecutor.
+type ExecutorOption func(*ExecutorConfig)
+
+func WithErrorABIs(abis ...*abi.ABI) ExecutorOption {
+	return func(c *ExecutorConfig) { c.ErrorABIs = append(c.ErrorABIs, abis...) }
+}
+
+func WithExecuteTimeout(timeout time.Duration) ExecutorOption {
+	return func(c *ExecutorConfig) { c.Timeout = timeout }
+}
+
+func WithNonceManager(nonces *NonceManager) ExecutorOption {
+	return func(c *ExecutorConfig) { c.Nonces = nonces }
+}
+
+// TransactionError is the error of a transaction that was mined but
+// reverted. It matches ErrTransactionReverted with errors.Is.
+//
+// The receipt of a reverted transaction carries no revert data, so the
+// executor replays the transaction with eth_call on the state of the
+// previous block. Data and Revert are the outcome of that replay. They are
+// empty when the replay does not revert the same way, for instance when the
+// transaction ran out of gas or depended on an earlier transaction of its
+// block, and ReplayErr then says why.
+type TransactionError struct {
+	Receipt   *types.Receipt
+	Data      []byte
+	Revert    *Revert
+	ReplayErr error
+}
+
+func (e *TransactionError) Error() string {
+	switch {
+	case e.Revert != nil:
+		return fmt.Sprintf("%s: %s: %s", ErrTransactionReverted, e.Receipt.TxHash.Hex(), e.Revert)
+	case e.ReplayErr != nil:
+		return fmt.Sprintf("%s: %s: %s", ErrTransactionReverted, e.Receipt.TxHash.Hex(), e.ReplayErr)
+	default:
+		return fmt.Sprintf("%s: %s", ErrTransactionReverted, e.Receipt.TxHash.Hex())
+	}
+}
+
+func (e *TransactionError) Unwrap() error { return ErrTransactionReverted }
+
+// Executor sends transactions through abigen bindings and waits for them to
+// be mined, treating reverted receipts as errors. Reverts caught by gas
+// estimation are returned as is, so either kind decodes with
+// RevertDecoder.DecodeError.
+type Executor struct {
+	backend Backend
+	chainID *big.Int
+	timeout time.Duration
+	decoder *RevertDecoder
+	nonces  *NonceManager
+}
+
+func NewExecutor(
+	backend Backend,
+	chainID *big.Int,
+	opts ...ExecutorOption,
+) *Executor {
+	config := DefaultExecutorConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return &Executor{
+		backend: backend,
+		chainID: chainID,
+		timeout: config.Timeout,
+		decoder: NewRevertDecoder(config.ErrorABIs...),
+		nonces:  config.Nonces,
+	}
+}
+
+// Execute sends the transaction transact builds, signed by from, and returns
+// its receipt once mined. A reverted receipt is returned along with a
+// *TransactionError.
+//
+// Example:
+//
+//	receipt, err := executor.Execute(ctx, owner, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+//		return contract.Transfer(opts, to, amount)
+//	})
+func (e *Executor) Execute(
+	ctx context.Context,
+	from Signer,
+	transact TransactFunc,
+) (*types.Receipt, error) {
+	opts, err := NewTransactor(from, e.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: creating transactor: %w", ErrExecutor, err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	tx, err := e.send(timeoutCtx, opts, transact)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sending transaction: %w", ErrExecutor, err)
+	}
+
+	receipt, err := bind.WaitMined(timeoutCtx, e.backend, tx)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		return nil, fmt.Errorf("%w: %s after %s", ErrExecuteTimeout, tx.Hash().Hex(), e.timeout)
+	case err != nil:
+		return nil, fmt.Errorf("%w: waiting for receipt: %w", ErrExecutor, err)
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		return receipt, e.replay(ctx, opts, tx, receipt)
+	}
+	return receipt, nil
+}
+
+func (e *Executor) send(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	transact TransactFunc,
+) (*types.Transaction, error) {
+	if e.nonces != nil {
+		return e.nonces.Transact(ctx, e.chainID, opts, transact)
+	}
+	opts.Context = ctx
+	return transact(opts)
+}
+
+// replay re-runs the reverted tx with eth_call to recover its revert data.
+func (e *Executor) replay(
+	ctx context.Context,
+	opts *bind.TransactOpts,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) *TransactionError {
+	txErr := &TransactionError{Receipt: receipt}
+
+	var parent *big.Int
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		parent = new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	}
+
+	msg := ethereum.CallMsg{
+		From:       opts.From,
+		To:         tx.To(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+	}
+	_, err := e.backend.CallContract(ctx, msg, parent)
+	if err == nil {
+		txErr.ReplayErr = fmt.Errorf("%w: replay did not revert", ErrRevertNoData)
+		return txErr
+	}
+
+	data, ok := RevertData(err)
+	if !ok {
+		txErr.ReplayErr = fmt.Errorf("%w: %w", ErrRevertNoData, err)
+		return txErr
+	}
+	txErr.Data = data
+
+	revert, err := e.decoder.Decode(data)
+	if err != nil {
+		txErr.ReplayErr = err
+		return txErr
+	}
+	txErr.Revert = revert
+	return txErr
+}
@@ -0,0 +1,258 @@
+package foundry_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/mocks"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+var (
+	tokenAddress = common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	transferData = hexutil.MustDecode("0xa9059cbb")
+)
+
+func TestExecutor_Execute(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		sent := expectTransaction(backend)
+		expectReceipt(backend, types.ReceiptStatusSuccessful)
+		executor := foundry.NewExecutor(backend, big.NewInt(foundry.ChainID))
+
+		// when
+		receipt, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		requireSender(t, account.Address(), *sent)
+		require.Equal(t, transferData, (*sent).Data())
+	})
+
+	t.Run("happy path - nonce manager", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		sent := expectTransaction(backend)
+		expectReceipt(backend, types.ReceiptStatusSuccessful)
+		nonces := newNonceManager(t, account, 7)
+		executor := foundry.NewExecutor(backend, big.NewInt(foundry.ChainID), foundry.WithNonceManager(nonces))
+
+		// when
+		_, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), (*sent).Nonce())
+	})
+
+	t.Run("error - reverted", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		expectTransaction(backend)
+		expectReceipt(backend, types.ReceiptStatusFailed)
+
+		data := packRevert(t, foundry.IERC20ErrorsABI, "ERC20InsufficientBalance", account.Address(), big.NewInt(0), big.NewInt(100))
+		replayErr := estimateGasError(t, fakeRPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)})
+		backend.EXPECT().
+			CallContract(mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+				return msg.From == account.Address() && *msg.To == tokenAddress
+			}), big.NewInt(4)).
+			Return(nil, replayErr)
+		executor := foundry.NewExecutor(backend, big.NewInt(foundry.ChainID))
+
+		// when
+		receipt, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrTransactionReverted)
+		require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+
+		var txErr *foundry.TransactionError
+		require.ErrorAs(t, err, &txErr)
+		require.Equal(t, receipt, txErr.Receipt)
+		require.True(t, txErr.Revert.Matches("ERC20InsufficientBalance", account.Address(), big.NewInt(0), big.NewInt(100)))
+
+		revert, decodeErr := foundry.NewRevertDecoder().DecodeError(err)
+		require.NoError(t, decodeErr)
+		require.Equal(t, txErr.Revert, revert)
+	})
+
+	t.Run("error - reverted with custom error", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		expectTransaction(backend)
+		expectReceipt(backend, types.ReceiptStatusFailed)
+
+		role := [32]byte{0x01}
+		data := packRevert(t, customErrorABI, "Unauthorized", account.Address(), role)
+		replayErr := estimateGasError(t, fakeRPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)})
+		backend.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(nil, replayErr)
+		executor := foundry.NewExecutor(
+			backend,
+			big.NewInt(foundry.ChainID),
+			foundry.WithErrorABIs(parseABI(t, customErrorABI)),
+		)
+
+		// when
+		_, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrTransactionReverted)
+		require.ErrorContains(t, err, "Unauthorized")
+
+		var txErr *foundry.TransactionError
+		require.ErrorAs(t, err, &txErr)
+		require.True(t, txErr.Revert.Matches("Unauthorized", account.Address(), role))
+	})
+
+	t.Run("error - replay does not revert", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		expectTransaction(backend)
+		expectReceipt(backend, types.ReceiptStatusFailed)
+		backend.EXPECT().CallContract(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		executor := foundry.NewExecutor(backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrTransactionReverted)
+
+		var txErr *foundry.TransactionError
+		require.ErrorAs(t, err, &txErr)
+		require.Nil(t, txErr.Revert)
+		require.ErrorIs(t, txErr.ReplayErr, foundry.ErrRevertNoData)
+
+		_, decodeErr := foundry.NewRevertDecoder().DecodeError(err)
+		require.ErrorIs(t, decodeErr, foundry.ErrRevertNoData)
+	})
+
+	t.Run("error - gas estimation reverts", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		expectPricing(backend)
+
+		data := packRevert(t, foundry.IERC20ErrorsABI, "ERC20InsufficientBalance", account.Address(), big.NewInt(0), big.NewInt(100))
+		estimateErr := estimateGasError(t, fakeRPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)})
+		backend.EXPECT().EstimateGas(mock.Anything, mock.Anything).Return(0, estimateErr)
+		executor := foundry.NewExecutor(backend, big.NewInt(foundry.ChainID))
+
+		// when
+		_, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrExecutor)
+		require.NotErrorIs(t, err, foundry.ErrTransactionReverted)
+
+		revert, decodeErr := foundry.NewRevertDecoder().DecodeError(err)
+		require.NoError(t, decodeErr)
+		require.Equal(t, "ERC20InsufficientBalance", revert.Name)
+	})
+
+	t.Run("error - timeout", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		backend := mocks.NewBackend(t)
+		expectTransaction(backend)
+		backend.EXPECT().TransactionReceipt(mock.Anything, mock.Anything).Return(nil, ethereum.NotFound)
+		executor := foundry.NewExecutor(
+			backend,
+			big.NewInt(foundry.ChainID),
+			foundry.WithExecuteTimeout(50*time.Millisecond),
+		)
+
+		// when
+		_, err := executor.Execute(t.Context(), account, rawTransact(backend))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrExecuteTimeout)
+	})
+
+	t.Run("error - no chain ID", func(t *testing.T) {
+		// given
+		executor := foundry.NewExecutor(mocks.NewBackend(t), nil)
+
+		// when
+		_, err := executor.Execute(t.Context(), requireAccount(t, 0), rawTransact(nil))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrExecutor)
+		require.ErrorIs(t, err, foundry.ErrSigner)
+	})
+}
+
+func TestTransactionError(t *testing.T) {
+	// given
+	err := error(&foundry.TransactionError{Receipt: &types.Receipt{TxHash: common.Hash{0x01}}})
+
+	// when
+	wrapped := fmt.Errorf("transfer: %w", err)
+
+	// then
+	require.ErrorIs(t, wrapped, foundry.ErrTransactionReverted)
+	require.ErrorIs(t, wrapped, foundry.ErrExecutor)
+	require.NotErrorIs(t, wrapped, foundry.ErrRevert)
+}
+
+// expectPricing stubs the calls bind makes to price an EIP-1559 call to
+// tokenAddress.
+func expectPricing(backend *mocks.Backend) {
+	backend.EXPECT().
+		HeaderByNumber(mock.Anything, mock.Anything).
+		Return(&types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(foundry.BaseFee)}, nil)
+	backend.EXPECT().SuggestGasTipCap(mock.Anything).Return(big.NewInt(1), nil)
+	backend.EXPECT().PendingNonceAt(mock.Anything, mock.Anything).Return(0, nil).Maybe()
+	backend.EXPECT().PendingCodeAt(mock.Anything, tokenAddress).Return([]byte{0x00}, nil)
+}
+
+// expectTransaction stubs sending a call to tokenAddress and returns where
+// the sent transaction is stored.
+func expectTransaction(backend *mocks.Backend) **types.Transaction {
+	expectPricing(backend)
+	backend.EXPECT().EstimateGas(mock.Anything, mock.Anything).Return(50_000, nil)
+
+	var sent *types.Transaction
+	backend.EXPECT().
+		SendTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, tx *types.Transaction) error {
+			sent = tx
+			return nil
+		})
+	return &sent
+}
+
+// expectReceipt stubs the receipt of any transaction as mined in block 5.
+func expectReceipt(backend *mocks.Backend, status uint64) {
+	backend.EXPECT().
+		TransactionReceipt(mock.Anything, mock.Anything).
+		Return(&types.Receipt{Status: status, BlockNumber: big.NewInt(5)}, nil)
+}
+
+// rawTransact stands in for an abigen binding method of a token at
+// tokenAddress.
+func rawTransact(backend *mocks.Backend) foundry.TransactFunc {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		contract := bind.NewBoundContract(tokenAddress, abi.ABI{}, backend, backend, backend)
+		return contract.RawTransact(opts, transferData)
+	}
+}
@@ -0,0 +1,275 @@
+package foundry
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// BroadcastRunPath is where `forge script` keeps the broadcast file of
+	// every execution, next to run-latest.json.
+	// It should be: <broadcast_dir>/<script_name>/<chain_id>/run-<timestamp>.json
+	//
+	// Example: ../../contracts/broadcast/HelloWorld.s.sol/31337/run-1769079932493.json
+	BroadcastRunPath = "%s/%s/%d/run-%d.json"
+
+	broadcastRunPrefix = "run-"
+	broadcastRunSuffix = ".json"
+)
+
+// ReadBroadcast loads the broadcast file at path.
+func ReadBroadcast(path string) (*Broadcast, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %w", ErrBroadcast, path, err)
+	}
+
+	broadcast := &Broadcast{}
+	err = json.Unmarshal(bytes, broadcast)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unmarshaling %s: %w", ErrBroadcast, path, err)
+	}
+	return broadcast, nil
+}
+
+// BroadcastRun is one execution of a script, as recorded by forge in a
+// run-<timestamp>.json file.
+type BroadcastRun struct {
+	Path string
+	// Chain is the chain ID the run broadcast to.
+	Chain uint64
+	// Timestamp is the Unix time, in milliseconds, of the run.
+	Timestamp uint64
+	Broadcast *Broadcast
+}
+
+// BroadcastHistory is every recorded run of a script, ordered by chain ID and
+// then from oldest to newest.
+type BroadcastHistory struct {
+	Runs []*BroadcastRun
+}
+
+// ReadBroadcastHistory loads every run-<timestamp>.json file forge wrote for
+// scriptName under broadcastDir. If chainIDs are given, only runs on those
+// chains are loaded. Dry runs and run-latest.json, which duplicates the newest
+// run, are skipped.
+//
+// Example:
+//
+//	history, err := ReadBroadcastHistory("../../contracts/broadcast", "BearCoin.s.sol", 31337)
+func ReadBroadcastHistory(
+	broadcastDir string,
+	scriptName string,
+	chainIDs ...uint64,
+) (*BroadcastHistory, error) {
+	scriptDir := filepath.Join(broadcastDir, scriptName)
+	entries, err := os.ReadDir(scriptDir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %w", ErrBroadcast, scriptDir, err)
+	}
+
+	history := &BroadcastHistory{Runs: []*BroadcastRun{}}
+	for _, entry := range entries {
+		chain, err := strconv.ParseUint(entry.Name(), 10, 64)
+		if !entry.IsDir() || err != nil {
+			continue
+		}
+		if len(chainIDs) > 0 && !slices.Contains(chainIDs, chain) {
+			continue
+		}
+
+		runs, err := readBroadcastRuns(filepath.Join(scriptDir, entry.Name()), chain)
+		if err != nil {
+			return nil, err
+		}
+		history.Runs = append(history.Runs, runs...)
+	}
+
+	slices.SortFunc(history.Runs, func(a, b *BroadcastRun) int {
+		return cmp.Or(cmp.Compare(a.Chain, b.Chain), cmp.Compare(a.Timestamp, b.Timestamp))
+	})
+	return history, nil
+}
+
+// Chains returns the chain IDs with at least one run, in ascending order.
+func (h *BroadcastHistory) Chains() []uint64 {
+	chains := []uint64{}
+	for _, run := range h.Runs {
+		if !slices.Contains(chains, run.Chain) {
+			chains = append(chains, run.Chain)
+		}
+	}
+	return chains
+}
+
+// Chain returns the runs on chainID, from oldest to newest.
+func (h *BroadcastHistory) Chain(chainID uint64) []*BroadcastRun {
+	runs := []*BroadcastRun{}
+	for _, run := range h.Runs {
+		if run.Chain == chainID {
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// Latest returns the newest run on chainID, or nil if there is none.
+func (h *BroadcastHistory) Latest(chainID uint64) *BroadcastRun {
+	runs := h.Chain(chainID)
+	if len(runs) == 0 {
+		return nil
+	}
+	return runs[len(runs)-1]
+}
+
+func readBroadcastRuns(chainDir string, chain uint64) ([]*BroadcastRun, error) {
+	entries, err := os.ReadDir(chainDir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %w", ErrBroadcast, chainDir, err)
+	}
+
+	runs := []*BroadcastRun{}
+	for _, entry := range entries {
+		timestamp, ok := parseBroadcastRunName(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		path := filepath.Join(chainDir, entry.Name())
+		broadcast, err := ReadBroadcast(path)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, &BroadcastRun{
+			Path:      path,
+			Chain:     chain,
+			Timestamp: timestamp,
+			Broadcast: broadcast,
+		})
+	}
+	return runs, nil
+}
+
+// parseBroadcastRunName returns the timestamp of a run-<timestamp>.json file
+// name, or false for any other name, including run-latest.json.
+func parseBroadcastRunName(name string) (uint64, bool) {
+	trimmed, ok := strings.CutPrefix(name, broadcastRunPrefix)
+	if !ok {
+		return 0, false
+	}
+	trimmed, ok = strings.CutSuffix(trimmed, broadcastRunSuffix)
+	if !ok {
+		return 0, false
+	}
+
+	timestamp, err := strconv.ParseUint(trimmed, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return timestamp, true
+}
+
+// ContractChange is a contract deployed by both runs of a BroadcastDiff.
+type ContractChange struct {
+	Name       string
+	OldAddress common.Address
+	NewAddress common.Address
+	// OldGasUsed and NewGasUsed are the gas used by the transactions that
+	// created the contract. They are zero while the transaction is pending.
+	OldGasUsed uint64
+	NewGasUsed uint64
+}
+
+// AddressChanged reports whether the contract moved to a new address.
+func (c *ContractChange) AddressChanged() bool {
+	return c.OldAddress != c.NewAddress
+}
+
+// GasDelta returns NewGasUsed minus OldGasUsed.
+func (c *ContractChange) GasDelta() *big.Int {
+	return gasDelta(c.OldGasUsed, c.NewGasUsed)
+}
+
+// BroadcastDiff compares the contracts two runs of a script deployed and the
+// gas they used. Contracts are matched by name and, for names deployed more
+// than once, by the order they were created in.
+type BroadcastDiff struct {
+	// Redeployed are the contracts deployed again by the new run.
+	Redeployed []*ContractChange
+	// Added are the contracts only the new run deployed.
+	Added []ContractAddress
+	// Removed are the contracts only the old run deployed.
+	Removed []ContractAddress
+	// OldGasUsed and NewGasUsed add up every receipt of each run.
+	OldGasUsed uint64
+	NewGasUsed uint64
+}
+
+// DiffBroadcasts compares an old run of a script against a newer one.
+func DiffBroadcasts(oldBroadcast *Broadcast, newBroadcast *Broadcast) *BroadcastDiff {
+	oldDeployment := NewDeployment(oldBroadcast)
+	newDeployment := NewDeployment(newBroadcast)
+	diff := &BroadcastDiff{
+		Redeployed: []*ContractChange{},
+		Added:      []ContractAddress{},
+		Removed:    []ContractAddress{},
+		OldGasUsed: oldDeployment.GasUsed,
+		NewGasUsed: newDeployment.GasUsed,
+	}
+
+	unmatched := slices.Clone(oldDeployment.Contracts)
+	for _, contract := range newDeployment.Contracts {
+		i := slices.IndexFunc(unmatched, func(old *DeploymentContract) bool {
+			return old.Name == contract.Name
+		})
+		if i < 0 {
+			diff.Added = append(diff.Added, ContractAddress{Name: contract.Name, Address: contract.Address})
+			continue
+		}
+
+		old := unmatched[i]
+		unmatched = slices.Delete(unmatched, i, i+1)
+		diff.Redeployed = append(diff.Redeployed, &ContractChange{
+			Name:       contract.Name,
+			OldAddress: old.Address,
+			NewAddress: contract.Address,
+			OldGasUsed: old.gasUsed(),
+			NewGasUsed: contract.gasUsed(),
+		})
+	}
+
+	for _, old := range unmatched {
+		diff.Removed = append(diff.Removed, ContractAddress{Name: old.Name, Address: old.Address})
+	}
+	return diff
+}
+
+// AddressChanges returns the redeployed contracts whose address changed.
+func (d *BroadcastDiff) AddressChanges() []*ContractChange {
+	changes := []*ContractChange{}
+	for _, change := range d.Redeployed {
+		if change.AddressChanged() {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// GasDelta returns NewGasUsed minus OldGasUsed.
+func (d *BroadcastDiff) GasDelta() *big.Int {
+	return gasDelta(d.OldGasUsed, d.NewGasUsed)
+}
+
+func gasDelta(oldGas uint64, newGas uint64) *big.Int {
+	delta := new(big.Int).SetUint64(newGas)
+	return delta.Sub(delta, new(big.Int).SetUint64(oldGas))
+}
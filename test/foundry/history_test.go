@@ -0,0 +1,194 @@
+package foundry_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+const scriptName = "BearCoin.s.sol"
+
+func TestReadBroadcastHistory(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		writeBroadcastRun(t, dir, 31337, "run-200.json", factoryBroadcastJSON)
+		writeBroadcastRun(t, dir, 31337, "run-100.json", broadcastJSON)
+		writeBroadcastRun(t, dir, 31337, "run-latest.json", factoryBroadcastJSON)
+		writeBroadcastRun(t, dir, 31337, "dry-run/run-300.json", broadcastJSON)
+		writeBroadcastRun(t, dir, 1, "run-150.json", broadcastJSON)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, scriptName, "multi"), 0o750))
+
+		// when
+		got, err := foundry.ReadBroadcastHistory(dir, scriptName)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got.Runs, 3)
+		require.Equal(t, uint64(1), got.Runs[0].Chain)
+		require.Equal(t, uint64(150), got.Runs[0].Timestamp)
+		require.Equal(t, uint64(100), got.Runs[1].Timestamp)
+		require.Equal(t, uint64(200), got.Runs[2].Timestamp)
+		require.Equal(t, filepath.Join(dir, scriptName, "31337", "run-200.json"), got.Runs[2].Path)
+		require.Equal(t, []string{"PairFactory", "Pair", "Pair"}, contractNames(got.Runs[2].Broadcast))
+
+		require.Equal(t, []uint64{1, 31337}, got.Chains())
+		require.Len(t, got.Chain(31337), 2)
+		require.Equal(t, got.Runs[2], got.Latest(31337))
+		require.Nil(t, got.Latest(5))
+	})
+
+	t.Run("happy path - filtered by chain", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		writeBroadcastRun(t, dir, 31337, "run-100.json", broadcastJSON)
+		writeBroadcastRun(t, dir, 1, "run-150.json", broadcastJSON)
+
+		// when
+		got, err := foundry.ReadBroadcastHistory(dir, scriptName, 31337)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got.Runs, 1)
+		require.Equal(t, []uint64{31337}, got.Chains())
+	})
+
+	t.Run("error - script not broadcast", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+
+		// when
+		_, err := foundry.ReadBroadcastHistory(dir, scriptName)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrBroadcast)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("error - invalid run", func(t *testing.T) {
+		// given
+		dir := t.TempDir()
+		writeBroadcastRun(t, dir, 31337, "run-100.json", []byte("{"))
+
+		// when
+		_, err := foundry.ReadBroadcastHistory(dir, scriptName)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrBroadcast)
+	})
+}
+
+func TestDiffBroadcasts(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		oldBroadcast := newCreateBroadcast(
+			createdContract{name: "Token", address: common.HexToAddress("0x01"), gasUsed: 100},
+			createdContract{name: "Vault", address: common.HexToAddress("0x02"), gasUsed: 200},
+			createdContract{name: "Oracle", address: common.HexToAddress("0x03"), gasUsed: 300},
+		)
+		newBroadcast := newCreateBroadcast(
+			createdContract{name: "Token", address: common.HexToAddress("0x01"), gasUsed: 150},
+			createdContract{name: "Vault", address: common.HexToAddress("0x04"), gasUsed: 200},
+			createdContract{name: "Router", address: common.HexToAddress("0x05"), gasUsed: 50},
+		)
+
+		// when
+		got := foundry.DiffBroadcasts(oldBroadcast, newBroadcast)
+
+		// then
+		require.Equal(t, []*foundry.ContractChange{
+			{
+				Name:       "Token",
+				OldAddress: common.HexToAddress("0x01"),
+				NewAddress: common.HexToAddress("0x01"),
+				OldGasUsed: 100,
+				NewGasUsed: 150,
+			},
+			{
+				Name:       "Vault",
+				OldAddress: common.HexToAddress("0x02"),
+				NewAddress: common.HexToAddress("0x04"),
+				OldGasUsed: 200,
+				NewGasUsed: 200,
+			},
+		}, got.Redeployed)
+		require.Equal(t, []foundry.ContractAddress{
+			{Name: "Router", Address: common.HexToAddress("0x05")},
+		}, got.Added)
+		require.Equal(t, []foundry.ContractAddress{
+			{Name: "Oracle", Address: common.HexToAddress("0x03")},
+		}, got.Removed)
+
+		require.Equal(t, []*foundry.ContractChange{got.Redeployed[1]}, got.AddressChanges())
+		require.Equal(t, big.NewInt(50), got.Redeployed[0].GasDelta())
+		require.Equal(t, uint64(600), got.OldGasUsed)
+		require.Equal(t, uint64(400), got.NewGasUsed)
+		require.Equal(t, big.NewInt(-200), got.GasDelta())
+	})
+
+	t.Run("happy path - factory contracts matched in order", func(t *testing.T) {
+		// given
+		oldBroadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		newBroadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		newBroadcast.Transactions[2].AdditionalContracts = nil
+
+		// when
+		got := foundry.DiffBroadcasts(oldBroadcast, newBroadcast)
+
+		// then
+		require.Len(t, got.Redeployed, 2)
+		require.Empty(t, got.AddressChanges())
+		require.Empty(t, got.Added)
+		require.Equal(t, []foundry.ContractAddress{
+			{Name: "Pair", Address: common.HexToAddress(secondPair)},
+		}, got.Removed)
+		require.Zero(t, got.GasDelta().Sign())
+	})
+}
+
+type createdContract struct {
+	name    string
+	address common.Address
+	gasUsed uint64
+}
+
+// newCreateBroadcast returns a broadcast with one mined CREATE transaction
+// per contract.
+func newCreateBroadcast(contracts ...createdContract) *foundry.Broadcast {
+	broadcast := &foundry.Broadcast{}
+	for i, contract := range contracts {
+		hash := common.BigToHash(big.NewInt(int64(i + 1)))
+		broadcast.Transactions = append(broadcast.Transactions, &foundry.Transaction{
+			Hash:            hash,
+			TransactionType: foundry.TransactionTypeCreate,
+			ContractName:    contract.name,
+			ContractAddress: &contract.address,
+		})
+		broadcast.Receipts = append(broadcast.Receipts, &foundry.Receipt{
+			TransactionHash: hash,
+			GasUsed:         contract.gasUsed,
+		})
+	}
+	return broadcast
+}
+
+func writeBroadcastRun(t *testing.T, dir string, chain int, name string, data []byte) {
+	t.Helper()
+	path := filepath.Join(dir, scriptName, strconv.Itoa(chain), name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func contractNames(broadcast *foundry.Broadcast) []string {
+	names := []string{}
+	for _, contract := range broadcast.ContractAddresses() {
+		names = append(names, contract.Name)
+	}
+	return names
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
@@ -11,13 +14,13 @@ var (
 )
 
 type Log struct {
-	Address          string
-	Topics           []string
-	Data             string
-	BlockHash        []byte
+	Address          common.Address
+	Topics           []common.Hash
+	Data             []byte
+	BlockHash        common.Hash
 	BlockNumber      uint64
 	BlockTimestamp   uint64
-	TransactionHash  []byte
+	TransactionHash  common.Hash
 	TransactionIndex uint64
 	LogIndex         uint64
 	Removed          bool
@@ -25,27 +28,41 @@ type Log struct {
 
 //nolint:tagliatelle
 type logJSON struct {
-	Address          string   `json:"address"`
-	Topics           []string `json:"topics"`
-	Data             string   `json:"data"`
-	BlockHash        string   `json:"blockHash"`
-	BlockNumber      string   `json:"blockNumber"`
-	BlockTimestamp   string   `json:"blockTimestamp"`
-	TransactionHash  string   `json:"transactionHash"`
-	TransactionIndex string   `json:"transactionIndex"`
-	LogIndex         string   `json:"logIndex"`
-	Removed          bool     `json:"removed"`
+	Address          common.Address `json:"address"`
+	Topics           []common.Hash  `json:"topics"`
+	Data             string         `json:"data"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	BlockNumber      string         `json:"blockNumber"`
+	BlockTimestamp   string         `json:"blockTimestamp"`
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	TransactionIndex string         `json:"transactionIndex"`
+	LogIndex         string         `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+// FromGeth sets l to the go-ethereum log.
+func (l *Log) FromGeth(log *types.Log) {
+	l.Address = log.Address
+	l.Topics = log.Topics
+	l.Data = log.Data
+	l.BlockHash = log.BlockHash
+	l.BlockNumber = log.BlockNumber
+	l.BlockTimestamp = log.BlockTimestamp
+	l.TransactionHash = log.TxHash
+	l.TransactionIndex = uint64(log.TxIndex)
+	l.LogIndex = uint64(log.Index)
+	l.Removed = log.Removed
 }
 
 func (l *Log) MarshalJSON() ([]byte, error) {
 	return json.Marshal(logJSON{
 		Address:          l.Address,
 		Topics:           l.Topics,
-		Data:             l.Data,
-		BlockHash:        BytesToHexString(l.BlockHash),
+		Data:             BytesToHexString(l.Data),
+		BlockHash:        l.BlockHash,
 		BlockNumber:      Uint64ToHexString(l.BlockNumber),
 		BlockTimestamp:   Uint64ToHexString(l.BlockTimestamp),
-		TransactionHash:  BytesToHexString(l.TransactionHash),
+		TransactionHash:  l.TransactionHash,
 		TransactionIndex: Uint64ToHexString(l.TransactionIndex),
 		LogIndex:         Uint64ToHexString(l.LogIndex),
 		Removed:          l.Removed,
@@ -59,9 +76,9 @@ func (l *Log) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: unmarshaling log: %w", ErrLog, err)
 	}
 
-	blockHash, err := ParseBytesFromHexString(log.BlockHash)
+	logData, err := ParseBytesFromHexString(log.Data)
 	if err != nil {
-		return fmt.Errorf("%w: decoding block hash: %w", ErrLog, err)
+		return fmt.Errorf("%w: decoding data: %w", ErrLog, err)
 	}
 
 	blockNumber, err := ParseUint64FromHexString(log.BlockNumber)
@@ -74,11 +91,6 @@ func (l *Log) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: parsing block timestamp: %w", ErrLog, err)
 	}
 
-	transactionHash, err := ParseBytesFromHexString(log.TransactionHash)
-	if err != nil {
-		return fmt.Errorf("%w: decoding transaction hash: %w", ErrLog, err)
-	}
-
 	transactionIndex, err := ParseUint64FromHexString(log.TransactionIndex)
 	if err != nil {
 		return fmt.Errorf("%w: parsing transaction index: %w", ErrLog, err)
@@ -91,13 +103,30 @@ func (l *Log) UnmarshalJSON(data []byte) error {
 
 	l.Address = log.Address
 	l.Topics = log.Topics
-	l.Data = log.Data
-	l.BlockHash = blockHash
+	l.Data = logData
+	l.BlockHash = log.BlockHash
 	l.BlockNumber = blockNumber
 	l.BlockTimestamp = blockTimestamp
-	l.TransactionHash = transactionHash
+	l.TransactionHash = log.TransactionHash
 	l.TransactionIndex = transactionIndex
 	l.LogIndex = logIndex
 	l.Removed = log.Removed
 	return nil
 }
+
+// ToGeth returns the log as a go-ethereum log, e.g. to decode it with the
+// Parse<Event> methods of abigen bindings.
+func (l *Log) ToGeth() *types.Log {
+	return &types.Log{
+		Address:        l.Address,
+		Topics:         l.Topics,
+		Data:           l.Data,
+		BlockNumber:    l.BlockNumber,
+		TxHash:         l.TransactionHash,
+		TxIndex:        uint(l.TransactionIndex),
+		BlockHash:      l.BlockHash,
+		BlockTimestamp: l.BlockTimestamp,
+		Index:          uint(l.LogIndex),
+		Removed:        l.Removed,
+	}
+}
@@ -2,9 +2,12 @@ package foundry_test
 
 import (
 	_ "embed"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/contracts/bindings"
 	"github.com/tahardi/bearchain/test/foundry"
 )
 
@@ -28,3 +31,40 @@ func TestLog_JSON(t *testing.T) {
 		require.JSONEq(t, string(want), string(got))
 	})
 }
+
+func TestLog_Geth(t *testing.T) {
+	t.Run("happy path - round trip", func(t *testing.T) {
+		// given
+		want := &foundry.Log{}
+		require.NoError(t, want.UnmarshalJSON(logJSON))
+
+		// when
+		got := &foundry.Log{}
+		got.FromGeth(want.ToGeth())
+
+		// then
+		require.Equal(t, want, got)
+	})
+
+	t.Run("happy path - parse with bindings", func(t *testing.T) {
+		// given
+		log := &foundry.Log{}
+		require.NoError(t, log.UnmarshalJSON(logJSON))
+
+		filterer, err := bindings.NewBearCoinFilterer(log.Address, nil)
+		require.NoError(t, err)
+
+		supply, ok := new(big.Int).SetString("d3c21bcecceda1000000", 16)
+		require.True(t, ok)
+
+		// when
+		transfer, err := filterer.ParseTransfer(*log.ToGeth())
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.Address{}, transfer.From)
+		require.Equal(t, requireAccount(t, 0).Address(), transfer.To)
+		require.Equal(t, supply, transfer.Value)
+		require.Equal(t, log.TransactionHash, transfer.Raw.TxHash)
+	})
+}
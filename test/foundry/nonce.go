@@ -0,0 +1,247 @@
+package foundry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// NonceTooLowMessage is how nodes, Anvil included, reject transactions
+	// whose nonce has already been used.
+	NonceTooLowMessage = "nonce too low"
+
+	// maxNonceRetries is how many times Transact resyncs and retries after a
+	// "nonce too low" rejection before giving up.
+	maxNonceRetries = 3
+)
+
+var (
+	ErrNonce        = errors.New("nonce")
+	ErrNonceTooLow  = fmt.Errorf("%w: too low", ErrNonce)
+	ErrNonceChainID = fmt.Errorf("%w: no chain ID", ErrNonce)
+)
+
+// NonceReader reads the next nonce of an account, counting pending
+// transactions, like an *ethclient.Client.
+type NonceReader interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// TransactFunc sends a transaction with opts, typically by calling an abigen
+// binding method.
+//
+// Example:
+//
+//	func(opts *bind.TransactOpts) (*types.Transaction, error) {
+//		return contract.Transfer(opts, to, amount)
+//	}
+type TransactFunc func(opts *bind.TransactOpts) (*types.Transaction, error)
+
+// NonceManager hands out nonces to concurrent senders without waiting for
+// their transactions to be mined, which the pending-nonce lookup of abigen
+// transactors cannot do. Nonces are tracked per account and chain ID, and
+// read from the node the first time an account sends.
+//
+// A NonceManager is safe to use concurrently. All transactions from its
+// accounts must go through it, or the nonces it hands out run into "nonce
+// too low" until Resync catches up.
+type NonceManager struct {
+	reader NonceReader
+
+	mu     sync.Mutex
+	nonces map[nonceKey]*accountNonces
+}
+
+func NewNonceManager(reader NonceReader) *NonceManager {
+	return &NonceManager{
+		reader: reader,
+		nonces: make(map[nonceKey]*accountNonces),
+	}
+}
+
+// Next reserves the next nonce of address on chainID. Nonces released by
+// failed sends are handed out again, lowest first, before new ones.
+func (m *NonceManager) Next(
+	ctx context.Context,
+	chainID *big.Int,
+	address common.Address,
+) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonces, err := m.load(ctx, chainID, address)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(nonces.released) > 0 {
+		nonce := nonces.released[0]
+		nonces.released = nonces.released[1:]
+		return nonce, nil
+	}
+
+	nonce := nonces.next
+	nonces.next++
+	return nonce, nil
+}
+
+// Release returns a nonce reserved with Next whose transaction was never
+// sent, so the next sender fills the gap instead of leaving later
+// transactions stuck behind it.
+func (m *NonceManager) Release(
+	chainID *big.Int,
+	address common.Address,
+	nonce uint64,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if chainID == nil {
+		return
+	}
+	nonces, ok := m.nonces[newNonceKey(chainID, address)]
+	if !ok || nonce >= nonces.next {
+		return
+	}
+
+	index, found := slices.BinarySearch(nonces.released, nonce)
+	if found {
+		return
+	}
+	nonces.released = slices.Insert(nonces.released, index, nonce)
+
+	// Released nonces at the top are handed out as new ones again.
+	for len(nonces.released) > 0 && nonces.released[len(nonces.released)-1] == nonces.next-1 {
+		nonces.released = nonces.released[:len(nonces.released)-1]
+		nonces.next--
+	}
+}
+
+// Resync catches address up with the pending nonce of the node, after
+// transactions were sent around the manager. Nonces already handed out are
+// kept, so it never hands out the same nonce twice.
+func (m *NonceManager) Resync(
+	ctx context.Context,
+	chainID *big.Int,
+	address common.Address,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonces, err := m.load(ctx, chainID, address)
+	if err != nil {
+		return err
+	}
+
+	pending, err := m.pendingNonce(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	nonces.next = max(nonces.next, pending)
+	nonces.released = slices.DeleteFunc(nonces.released, func(nonce uint64) bool {
+		return nonce < pending
+	})
+	return nil
+}
+
+// Transact sends a transaction from opts.From on chainID with the next nonce,
+// leaving opts untouched. It resyncs and retries if the nonce turns out to be
+// used already, and releases the nonce if the send fails otherwise.
+func (m *NonceManager) Transact(
+	ctx context.Context,
+	chainID *big.Int,
+	opts *bind.TransactOpts,
+	transact TransactFunc,
+) (*types.Transaction, error) {
+	for attempt := 0; ; attempt++ {
+		nonce, err := m.Next(ctx, chainID, opts.From)
+		if err != nil {
+			return nil, err
+		}
+
+		withNonce := *opts
+		withNonce.Context = ctx
+		withNonce.Nonce = new(big.Int).SetUint64(nonce)
+
+		tx, err := transact(&withNonce)
+		switch {
+		case err == nil:
+			return tx, nil
+		case !IsNonceTooLow(err):
+			m.Release(chainID, opts.From, nonce)
+			return nil, fmt.Errorf("%w: sending transaction with nonce %d: %w", ErrNonce, nonce, err)
+		case attempt == maxNonceRetries:
+			return nil, fmt.Errorf("%w: after %d retries: %w", ErrNonceTooLow, maxNonceRetries, err)
+		}
+
+		if err := m.Resync(ctx, chainID, opts.From); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// IsNonceTooLow reports whether err is a node rejecting a transaction because
+// its nonce has already been used. RPC errors only carry the message.
+func IsNonceTooLow(err error) bool {
+	return err != nil && strings.Contains(err.Error(), NonceTooLowMessage)
+}
+
+// load returns the nonces of address on chainID, reading them from the node
+// the first time. The caller must hold m.mu.
+func (m *NonceManager) load(
+	ctx context.Context,
+	chainID *big.Int,
+	address common.Address,
+) (*accountNonces, error) {
+	if chainID == nil {
+		return nil, ErrNonceChainID
+	}
+
+	key := newNonceKey(chainID, address)
+	if nonces, ok := m.nonces[key]; ok {
+		return nonces, nil
+	}
+
+	pending, err := m.pendingNonce(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	nonces := &accountNonces{next: pending}
+	m.nonces[key] = nonces
+	return nonces, nil
+}
+
+func (m *NonceManager) pendingNonce(ctx context.Context, address common.Address) (uint64, error) {
+	nonce, err := m.reader.PendingNonceAt(ctx, address)
+	if err != nil {
+		return 0, fmt.Errorf("%w: getting pending nonce of %s: %w", ErrNonce, address.Hex(), err)
+	}
+	return nonce, nil
+}
+
+type nonceKey struct {
+	chainID string
+	address common.Address
+}
+
+func newNonceKey(chainID *big.Int, address common.Address) nonceKey {
+	return nonceKey{chainID: chainID.String(), address: address}
+}
+
+// accountNonces are the nonces of one account: next is the lowest never
+// handed out, and released the ones below it given back, in ascending order.
+type accountNonces struct {
+	next     uint64
+	released []uint64
+}
@@ -0,0 +1,360 @@
+package foundry_test
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/mocks"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+var errNonceTooLow = errors.New("nonce too low: next nonce 9, tx nonce 5")
+
+func TestNonceManager_Next(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(5, nil).Once()
+		manager := foundry.NewNonceManager(reader)
+
+		// when
+		got := make([]uint64, 3)
+		for i := range got {
+			nonce, err := manager.Next(t.Context(), chainID, account.Address())
+			require.NoError(t, err)
+			got[i] = nonce
+		}
+
+		// then
+		require.Equal(t, []uint64{5, 6, 7}, got)
+	})
+
+	t.Run("happy path - per account and chain ID", func(t *testing.T) {
+		// given
+		first, second := requireAccount(t, 0), requireAccount(t, 1)
+		chainID, otherChainID := big.NewInt(foundry.ChainID), big.NewInt(1)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, first.Address()).Return(5, nil).Twice()
+		reader.EXPECT().PendingNonceAt(mock.Anything, second.Address()).Return(2, nil).Once()
+		manager := foundry.NewNonceManager(reader)
+		_, err := manager.Next(t.Context(), chainID, first.Address())
+		require.NoError(t, err)
+
+		// when
+		gotSecond, err := manager.Next(t.Context(), chainID, second.Address())
+		require.NoError(t, err)
+		gotOtherChain, err := manager.Next(t.Context(), otherChainID, first.Address())
+		require.NoError(t, err)
+		gotFirst, err := manager.Next(t.Context(), chainID, first.Address())
+		require.NoError(t, err)
+
+		// then
+		require.Equal(t, uint64(2), gotSecond)
+		require.Equal(t, uint64(5), gotOtherChain)
+		require.Equal(t, uint64(6), gotFirst)
+	})
+
+	t.Run("happy path - concurrent", func(t *testing.T) {
+		// given
+		const senders = 50
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, nil).Once()
+		manager := foundry.NewNonceManager(reader)
+
+		// when
+		got := make([]uint64, senders)
+		var wg sync.WaitGroup
+		for i := range got {
+			wg.Go(func() {
+				nonce, err := manager.Next(t.Context(), chainID, account.Address())
+				if err == nil {
+					got[i] = nonce
+				}
+			})
+		}
+		wg.Wait()
+
+		// then
+		slices.Sort(got)
+		for want := range uint64(senders) {
+			require.Equal(t, want, got[want])
+		}
+	})
+
+	t.Run("error - reader fails", func(t *testing.T) {
+		// given
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, mock.Anything).Return(0, errConnectionRefused)
+		manager := foundry.NewNonceManager(reader)
+
+		// when
+		_, err := manager.Next(t.Context(), big.NewInt(foundry.ChainID), requireAccount(t, 0).Address())
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNonce)
+		require.ErrorIs(t, err, errConnectionRefused)
+	})
+
+	t.Run("error - no chain ID", func(t *testing.T) {
+		// given
+		manager := foundry.NewNonceManager(mocks.NewNonceReader(t))
+
+		// when
+		_, err := manager.Next(t.Context(), nil, requireAccount(t, 0).Address())
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNonceChainID)
+	})
+}
+
+func TestNonceManager_Release(t *testing.T) {
+	t.Run("happy path - gap", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		manager := newNonceManager(t, account, 0)
+		requireNonces(t, manager, chainID, account, 0, 1, 2, 3)
+
+		// when
+		manager.Release(chainID, account.Address(), 2)
+		manager.Release(chainID, account.Address(), 1)
+		manager.Release(chainID, account.Address(), 1)
+
+		// then
+		requireNonces(t, manager, chainID, account, 1, 2, 4)
+	})
+
+	t.Run("happy path - top", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		manager := newNonceManager(t, account, 0)
+		requireNonces(t, manager, chainID, account, 0, 1, 2)
+
+		// when
+		manager.Release(chainID, account.Address(), 1)
+		manager.Release(chainID, account.Address(), 2)
+
+		// then
+		requireNonces(t, manager, chainID, account, 1, 2, 3)
+	})
+
+	t.Run("happy path - never handed out", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		manager := newNonceManager(t, account, 0)
+		requireNonces(t, manager, chainID, account, 0)
+
+		// when
+		manager.Release(chainID, account.Address(), 7)
+		manager.Release(big.NewInt(1), account.Address(), 0)
+
+		// then
+		requireNonces(t, manager, chainID, account, 1, 2)
+	})
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	t.Run("happy path - sent around the manager", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, nil).Once()
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(5, nil).Once()
+		manager := foundry.NewNonceManager(reader)
+		requireNonces(t, manager, chainID, account, 0, 1, 2)
+		manager.Release(chainID, account.Address(), 1)
+
+		// when
+		err := manager.Resync(t.Context(), chainID, account.Address())
+
+		// then
+		require.NoError(t, err)
+		requireNonces(t, manager, chainID, account, 5, 6)
+	})
+
+	t.Run("happy path - behind the manager", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, nil).Once()
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(1, nil).Once()
+		manager := foundry.NewNonceManager(reader)
+		requireNonces(t, manager, chainID, account, 0, 1, 2, 3)
+		manager.Release(chainID, account.Address(), 2)
+
+		// when
+		err := manager.Resync(t.Context(), chainID, account.Address())
+
+		// then
+		require.NoError(t, err)
+		requireNonces(t, manager, chainID, account, 2, 4)
+	})
+
+	t.Run("error - reader fails", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, nil).Once()
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, errConnectionRefused).Once()
+		manager := foundry.NewNonceManager(reader)
+		requireNonces(t, manager, chainID, account, 0)
+
+		// when
+		err := manager.Resync(t.Context(), chainID, account.Address())
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNonce)
+		require.ErrorIs(t, err, errConnectionRefused)
+	})
+}
+
+func TestNonceManager_Transact(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		manager := newNonceManager(t, account, 3)
+		opts := requireTransactor(t, account)
+
+		// when
+		tx, err := manager.Transact(t.Context(), chainID, opts, newNonceTx)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), tx.Nonce())
+		require.Nil(t, opts.Nonce)
+		requireNonces(t, manager, chainID, account, 4)
+	})
+
+	t.Run("happy path - nonce too low", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, nil).Once()
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(9, nil).Once()
+		manager := foundry.NewNonceManager(reader)
+		opts := requireTransactor(t, account)
+
+		var tried []uint64
+		transact := func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			tried = append(tried, opts.Nonce.Uint64())
+			if opts.Nonce.Uint64() < 9 {
+				return nil, errNonceTooLow
+			}
+			return newNonceTx(opts)
+		}
+
+		// when
+		tx, err := manager.Transact(t.Context(), chainID, opts, transact)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint64(9), tx.Nonce())
+		require.Equal(t, []uint64{0, 9}, tried)
+	})
+
+	t.Run("error - send fails", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		manager := newNonceManager(t, account, 0)
+		opts := requireTransactor(t, account)
+		requireNonces(t, manager, chainID, account, 0)
+
+		transact := func(*bind.TransactOpts) (*types.Transaction, error) {
+			return nil, errInsufficientFunds
+		}
+
+		// when
+		_, err := manager.Transact(t.Context(), chainID, opts, transact)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNonce)
+		require.ErrorIs(t, err, errInsufficientFunds)
+		requireNonces(t, manager, chainID, account, 1)
+	})
+
+	t.Run("error - nonce stays too low", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		reader := mocks.NewNonceReader(t)
+		reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(0, nil)
+		manager := foundry.NewNonceManager(reader)
+		opts := requireTransactor(t, account)
+
+		transact := func(*bind.TransactOpts) (*types.Transaction, error) {
+			return nil, errNonceTooLow
+		}
+
+		// when
+		_, err := manager.Transact(t.Context(), chainID, opts, transact)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrNonceTooLow)
+		require.ErrorIs(t, err, errNonceTooLow)
+	})
+}
+
+func TestIsNonceTooLow(t *testing.T) {
+	require.True(t, foundry.IsNonceTooLow(errNonceTooLow))
+	require.False(t, foundry.IsNonceTooLow(errInsufficientFunds))
+	require.False(t, foundry.IsNonceTooLow(nil))
+}
+
+// newNonceManager returns a manager for which account has sent pending
+// transactions so far.
+func newNonceManager(t *testing.T, account *foundry.Account, pending uint64) *foundry.NonceManager {
+	t.Helper()
+	reader := mocks.NewNonceReader(t)
+	reader.EXPECT().PendingNonceAt(mock.Anything, account.Address()).Return(pending, nil).Once()
+	return foundry.NewNonceManager(reader)
+}
+
+// newNonceTx stands in for an abigen binding method.
+func newNonceTx(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID: big.NewInt(foundry.ChainID),
+		Nonce:   opts.Nonce.Uint64(),
+		Gas:     21_000,
+	}))
+}
+
+func requireNonces(
+	t *testing.T,
+	manager *foundry.NonceManager,
+	chainID *big.Int,
+	account *foundry.Account,
+	want ...uint64,
+) {
+	t.Helper()
+	for _, nonce := range want {
+		got, err := manager.Next(t.Context(), chainID, account.Address())
+		require.NoError(t, err)
+		require.Equal(t, nonce, got)
+	}
+}
+
+func requireTransactor(t *testing.T, account *foundry.Account) *bind.TransactOpts {
+	t.Helper()
+	opts, err := foundry.NewTransactor(account, big.NewInt(foundry.ChainID))
+	require.NoError(t, err)
+	return opts
+}
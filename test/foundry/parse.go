@@ -2,6 +2,9 @@ package foundry
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -12,6 +15,18 @@ const (
 	NumSize         = 64
 )
 
+var (
+	ErrParse = errors.New("parse")
+)
+
+// BigToHexString formats n as a JSON-RPC quantity. A nil n is zero.
+func BigToHexString(n *big.Int) string {
+	if n == nil {
+		return HexStringPrefix + "0"
+	}
+	return HexStringPrefix + n.Text(HexBase)
+}
+
 func BytesToHexString(b []byte) string {
 	return HexStringPrefix + strings.ToLower(hex.EncodeToString(b))
 }
@@ -20,6 +35,17 @@ func Uint64ToHexString(n uint64) string {
 	return HexStringPrefix + strconv.FormatUint(n, HexBase)
 }
 
+func ParseBigFromHexString(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(
+		strings.ToLower(strings.TrimPrefix(s, HexStringPrefix)),
+		HexBase,
+	)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("%w: invalid hex quantity %q", ErrParse, s)
+	}
+	return n, nil
+}
+
 func ParseBytesFromHexString(s string) ([]byte, error) {
 	return hex.DecodeString(
 		strings.ToLower(
@@ -4,27 +4,32 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
 	ErrReceipt = errors.New("receipt")
 )
 
+// Receipt is a transaction receipt as forge records it. BlobGasPrice is nil
+// for receipts without one, such as those of nodes predating EIP-4844.
 type Receipt struct {
 	Status            uint64
 	CumulativeGasUsed uint64
 	Logs              []*Log
-	LogsBloom         []byte
+	LogsBloom         types.Bloom
 	Type              uint64
-	TransactionHash   []byte
+	TransactionHash   common.Hash
 	TransactionIndex  uint64
-	BlockHash         []byte
+	BlockHash         common.Hash
 	BlockNumber       uint64
 	GasUsed           uint64
-	EffectiveGasPrice uint64
-	BlobGasPrice      uint64
+	EffectiveGasPrice *big.Int
+	BlobGasPrice      *big.Int
 	From              *common.Address
 	To                *common.Address
 	ContractAddress   *common.Address
@@ -32,37 +37,74 @@ type Receipt struct {
 
 //nolint:tagliatelle
 type receiptJSON struct {
-	Status            string `json:"status"`
-	CumulativeGasUsed string `json:"cumulativeGasUsed"`
-	Logs              []*Log `json:"logs"`
-	LogsBloom         string `json:"logsBloom"`
-	Type              string `json:"type"`
-	TransactionHash   string `json:"transactionHash"`
-	TransactionIndex  string `json:"transactionIndex"`
-	BlockHash         string `json:"blockHash"`
-	BlockNumber       string `json:"blockNumber"`
-	GasUsed           string `json:"gasUsed"`
-	EffectiveGasPrice string `json:"effectiveGasPrice"`
-	BlobGasPrice      string `json:"blobGasPrice"`
+	Status            string          `json:"status"`
+	CumulativeGasUsed string          `json:"cumulativeGasUsed"`
+	Logs              []*Log          `json:"logs"`
+	LogsBloom         types.Bloom     `json:"logsBloom"`
+	Type              string          `json:"type"`
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	TransactionIndex  string          `json:"transactionIndex"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       string          `json:"blockNumber"`
+	GasUsed           string          `json:"gasUsed"`
+	EffectiveGasPrice string          `json:"effectiveGasPrice"`
+	BlobGasPrice      string          `json:"blobGasPrice,omitempty"`
 	From              *common.Address `json:"from"`
 	To                *common.Address `json:"to"`
 	ContractAddress   *common.Address `json:"contractAddress"`
 }
 
+// FromGeth sets r to the go-ethereum receipt. A go-ethereum receipt does not
+// record its sender and recipient, so From and To are cleared.
+func (r *Receipt) FromGeth(receipt *types.Receipt) {
+	logs := make([]*Log, len(receipt.Logs))
+	for i, log := range receipt.Logs {
+		logs[i] = &Log{}
+		logs[i].FromGeth(log)
+	}
+
+	var contractAddress *common.Address
+	if receipt.ContractAddress != (common.Address{}) {
+		address := receipt.ContractAddress
+		contractAddress = &address
+	}
+
+	var blockNumber uint64
+	if receipt.BlockNumber != nil {
+		blockNumber = receipt.BlockNumber.Uint64()
+	}
+
+	r.Status = receipt.Status
+	r.CumulativeGasUsed = receipt.CumulativeGasUsed
+	r.Logs = logs
+	r.LogsBloom = receipt.Bloom
+	r.Type = uint64(receipt.Type)
+	r.TransactionHash = receipt.TxHash
+	r.TransactionIndex = uint64(receipt.TransactionIndex)
+	r.BlockHash = receipt.BlockHash
+	r.BlockNumber = blockNumber
+	r.GasUsed = receipt.GasUsed
+	r.EffectiveGasPrice = receipt.EffectiveGasPrice
+	r.BlobGasPrice = receipt.BlobGasPrice
+	r.From = nil
+	r.To = nil
+	r.ContractAddress = contractAddress
+}
+
 func (r *Receipt) MarshalJSON() ([]byte, error) {
 	return json.Marshal(receiptJSON{
 		Status:            Uint64ToHexString(r.Status),
 		CumulativeGasUsed: Uint64ToHexString(r.CumulativeGasUsed),
 		Logs:              r.Logs,
-		LogsBloom:         BytesToHexString(r.LogsBloom),
+		LogsBloom:         r.LogsBloom,
 		Type:              Uint64ToHexString(r.Type),
-		TransactionHash:   BytesToHexString(r.TransactionHash),
+		TransactionHash:   r.TransactionHash,
 		TransactionIndex:  Uint64ToHexString(r.TransactionIndex),
-		BlockHash:         BytesToHexString(r.BlockHash),
+		BlockHash:         r.BlockHash,
 		BlockNumber:       Uint64ToHexString(r.BlockNumber),
 		GasUsed:           Uint64ToHexString(r.GasUsed),
-		EffectiveGasPrice: Uint64ToHexString(r.EffectiveGasPrice),
-		BlobGasPrice:      Uint64ToHexString(r.BlobGasPrice),
+		EffectiveGasPrice: BigToHexString(r.EffectiveGasPrice),
+		BlobGasPrice:      optionalBigToHexString(r.BlobGasPrice),
 		From:              r.From,
 		To:                r.To,
 		ContractAddress:   r.ContractAddress,
@@ -86,31 +128,16 @@ func (r *Receipt) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: parsing cumulative gas used: %w", ErrReceipt, err)
 	}
 
-	logsBloom, err := ParseBytesFromHexString(receipt.LogsBloom)
-	if err != nil {
-		return fmt.Errorf("%w: decoding logs bloom: %w", ErrReceipt, err)
-	}
-
 	rType, err := ParseUint64FromHexString(receipt.Type)
 	if err != nil {
 		return fmt.Errorf("%w: parsing receipt type: %w", ErrReceipt, err)
 	}
 
-	transactionHash, err := ParseBytesFromHexString(receipt.TransactionHash)
-	if err != nil {
-		return fmt.Errorf("%w: decoding transaction hash: %w", ErrReceipt, err)
-	}
-
 	transactionIndex, err := ParseUint64FromHexString(receipt.TransactionIndex)
 	if err != nil {
 		return fmt.Errorf("%w: parsing transaction index: %w", ErrReceipt, err)
 	}
 
-	blockHash, err := ParseBytesFromHexString(receipt.BlockHash)
-	if err != nil {
-		return fmt.Errorf("%w: parsing block hash: %w", ErrReceipt, err)
-	}
-
 	blockNumber, err := ParseUint64FromHexString(receipt.BlockNumber)
 	if err != nil {
 		return fmt.Errorf("%w: parsing block number: %w", ErrReceipt, err)
@@ -121,12 +148,12 @@ func (r *Receipt) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: parsing gas used: %w", ErrReceipt, err)
 	}
 
-	effectiveGasPrice, err := ParseUint64FromHexString(receipt.EffectiveGasPrice)
+	effectiveGasPrice, err := ParseBigFromHexString(receipt.EffectiveGasPrice)
 	if err != nil {
 		return fmt.Errorf("%w: parsing effective gas price: %w", ErrReceipt, err)
 	}
 
-	blobGasPrice, err := ParseUint64FromHexString(receipt.BlobGasPrice)
+	blobGasPrice, err := parseOptionalBigFromHexString(receipt.BlobGasPrice)
 	if err != nil {
 		return fmt.Errorf("%w: parsing blob gas price: %w", ErrReceipt, err)
 	}
@@ -134,11 +161,11 @@ func (r *Receipt) UnmarshalJSON(data []byte) error {
 	r.Status = status
 	r.CumulativeGasUsed = cumulativeGasUsed
 	r.Logs = receipt.Logs
-	r.LogsBloom = logsBloom
+	r.LogsBloom = receipt.LogsBloom
 	r.Type = rType
-	r.TransactionHash = transactionHash
+	r.TransactionHash = receipt.TransactionHash
 	r.TransactionIndex = transactionIndex
-	r.BlockHash = blockHash
+	r.BlockHash = receipt.BlockHash
 	r.BlockNumber = blockNumber
 	r.GasUsed = gasUsed
 	r.EffectiveGasPrice = effectiveGasPrice
@@ -148,3 +175,37 @@ func (r *Receipt) UnmarshalJSON(data []byte) error {
 	r.ContractAddress = receipt.ContractAddress
 	return nil
 }
+
+// ToGeth returns the receipt as a go-ethereum receipt, the shape
+// bind.WaitMined returns.
+func (r *Receipt) ToGeth() (*types.Receipt, error) {
+	if r.Type > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: invalid receipt type %d", ErrReceipt, r.Type)
+	}
+
+	logs := make([]*types.Log, len(r.Logs))
+	for i, log := range r.Logs {
+		logs[i] = log.ToGeth()
+	}
+
+	var contractAddress common.Address
+	if r.ContractAddress != nil {
+		contractAddress = *r.ContractAddress
+	}
+
+	return &types.Receipt{
+		Type:              uint8(r.Type),
+		Status:            r.Status,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Bloom:             r.LogsBloom,
+		Logs:              logs,
+		TxHash:            r.TransactionHash,
+		ContractAddress:   contractAddress,
+		GasUsed:           r.GasUsed,
+		EffectiveGasPrice: r.EffectiveGasPrice,
+		BlobGasPrice:      r.BlobGasPrice,
+		BlockHash:         r.BlockHash,
+		BlockNumber:       new(big.Int).SetUint64(r.BlockNumber),
+		TransactionIndex:  uint(r.TransactionIndex),
+	}, nil
+}
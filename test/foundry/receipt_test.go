@@ -2,8 +2,11 @@ package foundry_test
 
 import (
 	_ "embed"
+	"encoding/json"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tahardi/bearchain/test/foundry"
 )
@@ -11,6 +14,9 @@ import (
 //go:embed testdata/receipt.json
 var receiptJSON []byte
 
+//go:embed testdata/receipt-large-gas-price.json
+var receiptLargeGasPriceJSON []byte
+
 func TestReceipt_JSON(t *testing.T) {
 	t.Run("happy path - round trip", func(t *testing.T) {
 		// given
@@ -27,4 +33,125 @@ func TestReceipt_JSON(t *testing.T) {
 		require.NoError(t, err)
 		require.JSONEq(t, string(want), string(got))
 	})
+
+	t.Run("happy path - no blob gas price", func(t *testing.T) {
+		// given
+		want := withoutBlobGasPrice(t)
+
+		// when
+		receipt := &foundry.Receipt{}
+		err := receipt.UnmarshalJSON(want)
+		require.NoError(t, err)
+
+		got, err := receipt.MarshalJSON()
+
+		// then
+		require.NoError(t, err)
+		require.Nil(t, receipt.BlobGasPrice)
+		require.JSONEq(t, string(want), string(got))
+	})
+
+	t.Run("happy path - gas prices above 64 bits", func(t *testing.T) {
+		// given
+		want := receiptLargeGasPriceJSON
+		effectiveGasPrice := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(7))
+		blobGasPrice := new(big.Int).Lsh(big.NewInt(3), 70)
+
+		// when
+		receipt := &foundry.Receipt{}
+		err := receipt.UnmarshalJSON(want)
+		require.NoError(t, err)
+
+		got, err := receipt.MarshalJSON()
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, effectiveGasPrice, receipt.EffectiveGasPrice)
+		require.Equal(t, blobGasPrice, receipt.BlobGasPrice)
+		require.JSONEq(t, string(want), string(got))
+	})
+}
+
+func TestReceipt_Geth(t *testing.T) {
+	t.Run("happy path - to geth", func(t *testing.T) {
+		// given
+		receipt := &foundry.Receipt{}
+		require.NoError(t, receipt.UnmarshalJSON(receiptJSON))
+
+		// when
+		got, err := receipt.ToGeth()
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, got.Status)
+		require.Equal(t, uint8(types.DynamicFeeTxType), got.Type)
+		require.Equal(t, receipt.TransactionHash, got.TxHash)
+		require.Equal(t, receipt.BlockHash, got.BlockHash)
+		require.Equal(t, big.NewInt(1), got.BlockNumber)
+		require.Equal(t, *receipt.ContractAddress, got.ContractAddress)
+		require.Equal(t, receipt.LogsBloom, got.Bloom)
+		require.Equal(t, types.CreateBloom(got), got.Bloom)
+		require.Len(t, got.Logs, 1)
+		require.Equal(t, receipt.TransactionHash, got.Logs[0].TxHash)
+	})
+
+	t.Run("happy path - round trip", func(t *testing.T) {
+		// given
+		want := &foundry.Receipt{}
+		require.NoError(t, want.UnmarshalJSON(receiptJSON))
+		geth, err := want.ToGeth()
+		require.NoError(t, err)
+
+		// when
+		got := &foundry.Receipt{}
+		got.FromGeth(geth)
+
+		// then
+		require.Nil(t, got.From)
+		require.Nil(t, got.To)
+		got.From = want.From
+		require.Equal(t, want, got)
+	})
+
+	t.Run("happy path - round trip without blob gas price", func(t *testing.T) {
+		// given
+		want := &foundry.Receipt{}
+		require.NoError(t, want.UnmarshalJSON(withoutBlobGasPrice(t)))
+		geth, err := want.ToGeth()
+		require.NoError(t, err)
+
+		// when
+		got := &foundry.Receipt{}
+		got.FromGeth(geth)
+
+		// then
+		require.Nil(t, geth.BlobGasPrice)
+		require.Nil(t, got.BlobGasPrice)
+		got.From = want.From
+		require.Equal(t, want, got)
+	})
+
+	t.Run("error - invalid type", func(t *testing.T) {
+		// given
+		receipt := &foundry.Receipt{Type: 256}
+
+		// when
+		_, err := receipt.ToGeth()
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrReceipt)
+	})
+}
+
+// withoutBlobGasPrice returns the receipt fixture without a blob gas price,
+// as nodes predating EIP-4844 report it.
+func withoutBlobGasPrice(t *testing.T) []byte {
+	t.Helper()
+	fixture := map[string]any{}
+	require.NoError(t, json.Unmarshal(receiptJSON, &fixture))
+	delete(fixture, "blobGasPrice")
+
+	data, err := json.Marshal(fixture)
+	require.NoError(t, err)
+	return data
 }
@@ -0,0 +1,265 @@
+package foundry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// RevertError is the name of the error `require` and `revert("...")`
+	// revert with.
+	RevertError = "Error"
+	// RevertPanic is the name of the error failed assertions, arithmetic
+	// overflows and the like revert with.
+	RevertPanic = "Panic"
+
+	// IERC20ErrorsABI are the custom errors of OpenZeppelin's ERC-20 tokens.
+	//
+	// https://github.com/OpenZeppelin/openzeppelin-contracts/blob/master/contracts/interfaces/draft-IERC6093.sol
+	IERC20ErrorsABI = `[
+		{"type":"error","name":"ERC20InsufficientBalance","inputs":[{"name":"sender","type":"address"},{"name":"balance","type":"uint256"},{"name":"needed","type":"uint256"}]},
+		{"type":"error","name":"ERC20InvalidSender","inputs":[{"name":"sender","type":"address"}]},
+		{"type":"error","name":"ERC20InvalidReceiver","inputs":[{"name":"receiver","type":"address"}]},
+		{"type":"error","name":"ERC20InsufficientAllowance","inputs":[{"name":"spender","type":"address"},{"name":"allowance","type":"uint256"},{"name":"needed","type":"uint256"}]},
+		{"type":"error","name":"ERC20InvalidApprover","inputs":[{"name":"approver","type":"address"}]},
+		{"type":"error","name":"ERC20InvalidSpender","inputs":[{"name":"spender","type":"address"}]}
+	]`
+
+	// BuiltinErrorsABI are the errors solc reverts with on its own.
+	BuiltinErrorsABI = `[
+		{"type":"error","name":"Error","inputs":[{"name":"reason","type":"string"}]},
+		{"type":"error","name":"Panic","inputs":[{"name":"code","type":"uint256"}]}
+	]`
+
+	revertSelectorLength = 4
+	// executionErrorCode is the JSON-RPC error code of a reverted call.
+	executionErrorCode = 3
+)
+
+// Panic codes of Panic(uint256).
+//
+// https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+const (
+	PanicGeneric           uint64 = 0x00
+	PanicAssert            uint64 = 0x01
+	PanicArithmetic        uint64 = 0x11
+	PanicDivisionByZero    uint64 = 0x12
+	PanicEnumConversion    uint64 = 0x21
+	PanicStorageEncoding   uint64 = 0x22
+	PanicEmptyArrayPop     uint64 = 0x31
+	PanicArrayOutOfBounds  uint64 = 0x32
+	PanicOutOfMemory       uint64 = 0x41
+	PanicUninitializedFunc uint64 = 0x51
+)
+
+var (
+	ErrRevert        = errors.New("revert")
+	ErrRevertNoData  = fmt.Errorf("%w: no revert data", ErrRevert)
+	ErrRevertUnknown = fmt.Errorf("%w: unknown error", ErrRevert)
+
+	panicReasons = map[uint64]string{
+		PanicGeneric:           "generic panic",
+		PanicAssert:            "assert(false)",
+		PanicArithmetic:        "arithmetic underflow or overflow",
+		PanicDivisionByZero:    "division or modulo by zero",
+		PanicEnumConversion:    "enum overflow",
+		PanicStorageEncoding:   "invalid encoded storage byte array",
+		PanicEmptyArrayPop:     "pop on empty array",
+		PanicArrayOutOfBounds:  "array index out of bounds",
+		PanicOutOfMemory:       "out of memory",
+		PanicUninitializedFunc: "call to uninitialized function",
+	}
+
+	builtinErrors = mustParseABI(BuiltinErrorsABI)
+	ierc20Errors  = mustParseABI(IERC20ErrorsABI)
+)
+
+// Revert is the error a contract call reverted with.
+//
+// Name is RevertError for `require` and `revert("...")`, RevertPanic for
+// compiler checks, or the name of a custom error. Args are the decoded
+// arguments: string for Error, *big.Int for Panic, and the Go types abigen
+// binds to for custom errors, such as common.Address and *big.Int.
+type Revert struct {
+	Name      string
+	Signature string
+	Args      []any
+	Data      []byte
+}
+
+// Reason returns the message of an Error(string) revert, or "".
+func (r *Revert) Reason() string {
+	if r.Name != RevertError || len(r.Args) != 1 {
+		return ""
+	}
+	reason, _ := r.Args[0].(string)
+	return reason
+}
+
+// PanicCode returns the code of a Panic(uint256) revert, or nil.
+func (r *Revert) PanicCode() *big.Int {
+	if r.Name != RevertPanic || len(r.Args) != 1 {
+		return nil
+	}
+	code, _ := r.Args[0].(*big.Int)
+	return code
+}
+
+// Matches reports whether the revert is the named error with the given
+// arguments. *big.Int arguments are compared by value.
+func (r *Revert) Matches(name string, args ...any) bool {
+	if r.Name != name || len(r.Args) != len(args) {
+		return false
+	}
+	for i, arg := range args {
+		if !argEqual(r.Args[i], arg) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Revert) String() string {
+	if code := r.PanicCode(); code != nil {
+		reason, ok := panicReasons[code.Uint64()]
+		if !code.IsUint64() || !ok {
+			reason = "unknown panic"
+		}
+		return fmt.Sprintf("%s(0x%x: %s)", RevertPanic, code, reason)
+	}
+
+	args := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		switch arg := arg.(type) {
+		case string:
+			args[i] = fmt.Sprintf("%q", arg)
+		case common.Address:
+			args[i] = arg.Hex()
+		default:
+			args[i] = fmt.Sprint(arg)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", r.Name, strings.Join(args, ", "))
+}
+
+// RevertDecoder decodes revert data into Error(string), Panic(uint256) and
+// the custom errors of the ABIs it was given.
+type RevertDecoder struct {
+	errors []abi.Error
+}
+
+// NewRevertDecoder returns a decoder for the custom errors of the given
+// contract ABIs. The errors of OpenZeppelin's IERC20Errors are always known.
+func NewRevertDecoder(abis ...*abi.ABI) *RevertDecoder {
+	d := &RevertDecoder{errors: []abi.Error{}}
+	for _, contractABI := range append([]*abi.ABI{builtinErrors, ierc20Errors}, abis...) {
+		for _, abiError := range contractABI.Errors {
+			d.add(abiError)
+		}
+	}
+	return d
+}
+
+// Decode decodes revert data.
+func (d *RevertDecoder) Decode(data []byte) (*Revert, error) {
+	if len(data) < revertSelectorLength {
+		return nil, fmt.Errorf("%w: %s", ErrRevertNoData, hexutil.Encode(data))
+	}
+
+	for _, abiError := range d.errors {
+		if !bytes.Equal(abiError.ID[:revertSelectorLength], data[:revertSelectorLength]) {
+			continue
+		}
+		args, err := abiError.Inputs.Unpack(data[revertSelectorLength:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: unpacking %s: %w", ErrRevert, abiError.Sig, err)
+		}
+		return &Revert{
+			Name:      abiError.Name,
+			Signature: abiError.Sig,
+			Args:      args,
+			Data:      data,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrRevertUnknown, hexutil.Encode(data))
+}
+
+// DecodeError decodes the revert data of an error returned by a node, such as
+// the error of a contract call or gas estimation, or by an Executor. It
+// returns ErrRevertNoData for failures that are not reverts, like running out
+// of gas.
+func (d *RevertDecoder) DecodeError(err error) (*Revert, error) {
+	if err == nil {
+		return nil, fmt.Errorf("%w: call did not fail", ErrRevertNoData)
+	}
+	data, ok := RevertData(err)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", ErrRevertNoData, err)
+	}
+	return d.Decode(data)
+}
+
+func (d *RevertDecoder) add(abiError abi.Error) {
+	for _, known := range d.errors {
+		if known.ID == abiError.ID {
+			return
+		}
+	}
+	d.errors = append(d.errors, abiError)
+}
+
+// RevertData returns the revert data of an error returned by a node.
+// Execution errors carry it as JSON-RPC error data, and Anvil and Geth
+// both report them with code 3. The *TransactionError of a mined transaction
+// carries the data of its replay.
+func RevertData(err error) ([]byte, bool) {
+	var txErr *TransactionError
+	if errors.As(err, &txErr) {
+		return txErr.Data, len(txErr.Data) > 0
+	}
+
+	var rpcErr rpc.Error
+	var dataErr rpc.DataError
+	if !errors.As(err, &rpcErr) || !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	if rpcErr.ErrorCode() != executionErrorCode {
+		return nil, false
+	}
+
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func argEqual(got any, want any) bool {
+	gotInt, gotOK := got.(*big.Int)
+	wantInt, wantOK := want.(*big.Int)
+	if gotOK && wantOK {
+		return gotInt.Cmp(wantInt) == 0
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+func mustParseABI(definition string) *abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return &parsed
+}
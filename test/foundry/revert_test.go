@@ -0,0 +1,193 @@
+package foundry_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+const (
+	customErrorABI = `[{"type":"error","name":"Unauthorized","inputs":[{"name":"caller","type":"address"},{"name":"role","type":"bytes32"}]}]`
+	estimateGas    = "eth_estimateGas"
+)
+
+var sender = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+func TestRevertDecoder_Decode(t *testing.T) {
+	t.Run("happy path - error string", func(t *testing.T) {
+		// given
+		data := packRevert(t, foundry.BuiltinErrorsABI, foundry.RevertError, "Not owner")
+
+		// when
+		got, err := foundry.NewRevertDecoder().Decode(data)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, foundry.RevertError, got.Name)
+		require.Equal(t, "Error(string)", got.Signature)
+		require.Equal(t, "Not owner", got.Reason())
+		require.Nil(t, got.PanicCode())
+		require.True(t, got.Matches(foundry.RevertError, "Not owner"))
+		require.Equal(t, `Error("Not owner")`, got.String())
+	})
+
+	t.Run("happy path - panic", func(t *testing.T) {
+		// given
+		code := new(big.Int).SetUint64(foundry.PanicArithmetic)
+		data := packRevert(t, foundry.BuiltinErrorsABI, foundry.RevertPanic, code)
+
+		// when
+		got, err := foundry.NewRevertDecoder().Decode(data)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, code, got.PanicCode())
+		require.Empty(t, got.Reason())
+		require.Equal(t, "Panic(0x11: arithmetic underflow or overflow)", got.String())
+	})
+
+	t.Run("happy path - erc20 error", func(t *testing.T) {
+		// given
+		data := packRevert(t, foundry.IERC20ErrorsABI, "ERC20InsufficientBalance", sender, big.NewInt(0), big.NewInt(100))
+
+		// when
+		got, err := foundry.NewRevertDecoder().Decode(data)
+
+		// then
+		require.NoError(t, err)
+		require.True(t, got.Matches("ERC20InsufficientBalance", sender, big.NewInt(0), big.NewInt(100)))
+		require.False(t, got.Matches("ERC20InsufficientBalance", sender, big.NewInt(0), big.NewInt(99)))
+		require.False(t, got.Matches("ERC20InsufficientAllowance", sender, big.NewInt(0), big.NewInt(100)))
+		require.Equal(t, "ERC20InsufficientBalance("+sender.Hex()+", 0, 100)", got.String())
+	})
+
+	t.Run("happy path - contract error", func(t *testing.T) {
+		// given
+		contractABI := parseABI(t, customErrorABI)
+		role := [32]byte{0x01}
+		data := packRevert(t, customErrorABI, "Unauthorized", sender, role)
+
+		// when
+		got, err := foundry.NewRevertDecoder(contractABI).Decode(data)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, "Unauthorized(address,bytes32)", got.Signature)
+		require.True(t, got.Matches("Unauthorized", sender, role))
+		require.Equal(t, data, got.Data)
+	})
+
+	t.Run("error - unknown error", func(t *testing.T) {
+		// given
+		data := packRevert(t, customErrorABI, "Unauthorized", sender, [32]byte{})
+
+		// when
+		_, err := foundry.NewRevertDecoder().Decode(data)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrRevertUnknown)
+		require.ErrorContains(t, err, hexutil.Encode(data[:4]))
+	})
+
+	t.Run("error - no data", func(t *testing.T) {
+		// given
+		data := []byte{}
+
+		// when
+		_, err := foundry.NewRevertDecoder().Decode(data)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrRevertNoData)
+	})
+
+	t.Run("error - malformed arguments", func(t *testing.T) {
+		// given
+		data := packRevert(t, foundry.BuiltinErrorsABI, foundry.RevertError, "Not owner")[:4+31]
+
+		// when
+		_, err := foundry.NewRevertDecoder().Decode(data)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrRevert)
+		require.NotErrorIs(t, err, foundry.ErrRevertUnknown)
+	})
+}
+
+func TestRevertDecoder_DecodeError(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		data := packRevert(t, foundry.IERC20ErrorsABI, "ERC20InsufficientAllowance", sender, big.NewInt(100), big.NewInt(200))
+		err := estimateGasError(t, fakeRPCError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)})
+
+		// when
+		got, decodeErr := foundry.NewRevertDecoder().DecodeError(err)
+
+		// then
+		require.NoError(t, decodeErr)
+		require.True(t, got.Matches("ERC20InsufficientAllowance", sender, big.NewInt(100), big.NewInt(200)))
+	})
+
+	t.Run("error - out of gas", func(t *testing.T) {
+		// given
+		err := estimateGasError(t, fakeRPCError{Code: -32000, Message: "out of gas"})
+
+		// when
+		_, decodeErr := foundry.NewRevertDecoder().DecodeError(err)
+
+		// then
+		require.ErrorIs(t, decodeErr, foundry.ErrRevertNoData)
+		require.ErrorContains(t, decodeErr, "out of gas")
+	})
+
+	t.Run("error - no error", func(t *testing.T) {
+		// given
+		var err error
+
+		// when
+		_, decodeErr := foundry.NewRevertDecoder().DecodeError(err)
+
+		// then
+		require.ErrorIs(t, decodeErr, foundry.ErrRevertNoData)
+	})
+}
+
+// estimateGasError returns the error a client gets when the node answers
+// eth_estimateGas with rpcErr.
+func estimateGasError(t *testing.T, rpcErr fakeRPCError) error {
+	t.Helper()
+	fake := startFakeRPC(t, map[string]any{estimateGas: rpcErr})
+	client, err := ethclient.DialContext(t.Context(), fmt.Sprintf("http://127.0.0.1:%d", fake.port))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	_, err = client.EstimateGas(t.Context(), ethereum.CallMsg{From: sender})
+	require.Error(t, err)
+	return err
+}
+
+// packRevert returns the revert data of the named error of definition.
+func packRevert(t *testing.T, definition string, name string, args ...any) []byte {
+	t.Helper()
+	abiError, ok := parseABI(t, definition).Errors[name]
+	require.True(t, ok)
+
+	packed, err := abiError.Inputs.Pack(args...)
+	require.NoError(t, err)
+	return append(abiError.ID[:4:4], packed...)
+}
+
+func parseABI(t *testing.T, definition string) *abi.ABI {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	require.NoError(t, err)
+	return &parsed
+}
@@ -0,0 +1,149 @@
+package foundry
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"os"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	GasPriceFlag = "--with-gas-price"
+	LegacyFlag   = "--legacy"
+	SigFlag      = "--sig"
+	SlowFlag     = "--slow"
+
+	// ScriptContract is the contract `forge script` runs by default.
+	// It should be: <contract_name>Script
+	//
+	// Example: HelloWorldScript
+	ScriptContract = "%sScript"
+)
+
+// ScriptConfig holds the inputs of a `forge script` run.
+type ScriptConfig struct {
+	// Sig is the function to run, either a signature like "run(uint256)" or
+	// ABI-encoded calldata. Empty runs `run()`.
+	Sig string
+	// SigArgs are the arguments of Sig when it is a signature.
+	SigArgs []string
+	// Env is added to the environment of forge, where the script reads it
+	// with vm.env*.
+	Env map[string]string
+	// Flags are passed to forge as is.
+	Flags []string
+	// TargetContract is the contract in the script file to run. Empty runs
+	// <contract_name>Script.
+	TargetContract string
+}
+
+func DefaultScriptConfig() *ScriptConfig {
+	return &ScriptConfig{
+		Sig:            "",
+		SigArgs:        nil,
+		Env:            map[string]string{},
+		Flags:          nil,
+		TargetContract: "",
+	}
+}
+
+// ScriptOption overrides a field of the ScriptConfig used by DeployContract.
+type ScriptOption func(*ScriptConfig)
+
+// WithCalldata runs the script function selected by ABI-encoded calldata,
+// such as the output of abi.ABI.Pack.
+func WithCalldata(calldata []byte) ScriptOption {
+	return func(c *ScriptConfig) {
+		c.Sig = hexutil.Encode(calldata)
+		c.SigArgs = nil
+	}
+}
+
+// WithEnv sets an environment variable for the script to read with vm.env*.
+func WithEnv(key string, value string) ScriptOption {
+	return func(c *ScriptConfig) { c.Env[key] = value }
+}
+
+// WithForgeFlags appends flags to the `forge script` command.
+func WithForgeFlags(flags ...string) ScriptOption {
+	return func(c *ScriptConfig) { c.Flags = append(c.Flags, flags...) }
+}
+
+// WithGasPrice sets the gas price, in wei, of the broadcast transactions.
+func WithGasPrice(price *big.Int) ScriptOption {
+	return WithForgeFlags(GasPriceFlag, price.String())
+}
+
+// WithLegacy broadcasts legacy transactions instead of EIP-1559 ones.
+func WithLegacy() ScriptOption {
+	return WithForgeFlags(LegacyFlag)
+}
+
+// WithSig runs the script function with the given signature, e.g.
+// "run(address,uint256)", passing args in forge's CLI format.
+func WithSig(sig string, args ...string) ScriptOption {
+	return func(c *ScriptConfig) {
+		c.Sig = sig
+		c.SigArgs = args
+	}
+}
+
+// WithSlow sends each transaction only after the previous one is mined.
+func WithSlow() ScriptOption {
+	return WithForgeFlags(SlowFlag)
+}
+
+// WithTargetContract runs a contract of the script file other than
+// <contract_name>Script.
+func WithTargetContract(name string) ScriptOption {
+	return func(c *ScriptConfig) { c.TargetContract = name }
+}
+
+// ScriptResult is the outcome of DeployContract.
+type ScriptResult struct {
+	// Address is the address of the contract DeployContract was asked for.
+	Address *common.Address
+	// Deployment describes everything the script broadcast.
+	Deployment *Deployment
+	// Broadcast is the run-latest.json file the script wrote.
+	Broadcast *Broadcast
+}
+
+func scriptArgs(
+	config *ScriptConfig,
+	scriptDir string,
+	contractName string,
+	url string,
+	wallet []string,
+) []string {
+	target := config.TargetContract
+	if target == "" {
+		target = fmt.Sprintf(ScriptContract, contractName)
+	}
+
+	scriptName := fmt.Sprintf(ScriptName, contractName)
+	args := []string{
+		ScriptCommand,
+		fmt.Sprintf(ScriptPath, scriptDir, scriptName, target),
+		RPCFlag, url,
+	}
+	args = append(args, wallet...)
+	args = append(args, BroadcastFlag)
+	if config.Sig != "" {
+		args = append(args, SigFlag, config.Sig)
+		args = append(args, config.SigArgs...)
+	}
+	return append(args, config.Flags...)
+}
+
+func scriptEnv(config *ScriptConfig) []string {
+	env := os.Environ()
+	for _, key := range slices.Sorted(maps.Keys(config.Env)) {
+		env = append(env, key+"="+config.Env[key])
+	}
+	return env
+}
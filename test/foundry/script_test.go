@@ -0,0 +1,222 @@
+package foundry_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+// fakeForge records the arguments and the FOO environment variable it was
+// run with in the directory named by FAKE_FORGE_DIR.
+const fakeForge = `printf '%s\n' "$@" > "$FAKE_FORGE_DIR/args"
+printf '%s' "$FOO" > "$FAKE_FORGE_DIR/env"`
+
+func TestAnvil_DeployContract(t *testing.T) {
+	t.Run("happy path - defaults", func(t *testing.T) {
+		// given
+		anvil, dir := newScriptAnvil(t, fakeForge, contractName)
+		owner := anvil.Account(0)
+		want := []string{
+			foundry.ScriptCommand,
+			"scripts/BearCoin.s.sol:BearCoinScript",
+			foundry.RPCFlag, anvil.URL(),
+			foundry.UnlockedFlag,
+			foundry.SenderFlag, owner.Address().Hex(),
+			foundry.BroadcastFlag,
+		}
+
+		// when
+		got, err := anvil.DeployContract(t.Context(), contractName, owner)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(contractAddress), *got.Address)
+		require.Len(t, got.Deployment.Contracts, 1)
+		require.Equal(t, contractName, got.Deployment.Contracts[0].Name)
+		require.Equal(t, unmarshalBroadcast(t, broadcastJSON), got.Broadcast)
+		require.Equal(t, want, readForgeArgs(t, dir))
+	})
+
+	t.Run("happy path - options", func(t *testing.T) {
+		// given
+		anvil, dir := newScriptAnvil(t, fakeForge, contractName)
+		owner := anvil.Account(0)
+		want := []string{
+			foundry.ScriptCommand,
+			"scripts/BearCoin.s.sol:BearCoinV2Script",
+			foundry.RPCFlag, anvil.URL(),
+			foundry.UnlockedFlag,
+			foundry.SenderFlag, owner.Address().Hex(),
+			foundry.BroadcastFlag,
+			foundry.SigFlag, "run(string,uint256)", "Bear", "42",
+			foundry.SlowFlag,
+			foundry.LegacyFlag,
+			foundry.GasPriceFlag, "7",
+		}
+
+		// when
+		_, err := anvil.DeployContract(
+			t.Context(),
+			contractName,
+			owner,
+			foundry.WithSig("run(string,uint256)", "Bear", "42"),
+			foundry.WithEnv("FOO", "bar"),
+			foundry.WithSlow(),
+			foundry.WithLegacy(),
+			foundry.WithGasPrice(big.NewInt(7)),
+			foundry.WithTargetContract("BearCoinV2Script"),
+		)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, want, readForgeArgs(t, dir))
+
+		env, err := os.ReadFile(filepath.Join(dir, "env"))
+		require.NoError(t, err)
+		require.Equal(t, "bar", string(env))
+	})
+
+	t.Run("happy path - calldata", func(t *testing.T) {
+		// given
+		anvil, dir := newScriptAnvil(t, fakeForge, contractName)
+		calldata := []byte{0xc0, 0x40, 0x62, 0x26}
+
+		// when
+		_, err := anvil.DeployContract(
+			t.Context(),
+			contractName,
+			anvil.Account(0),
+			foundry.WithCalldata(calldata),
+		)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []string{foundry.SigFlag, "0xc0406226"}, readForgeArgs(t, dir)[8:])
+	})
+
+	t.Run("happy path - keystore", func(t *testing.T) {
+		// given
+		body := fakeForge + `
+while [ "$1" != "` + foundry.PasswordFileFlag + `" ]; do shift; done
+cat "$2" > "$FAKE_FORGE_DIR/password"`
+		anvil, dir := newScriptAnvil(t, body, contractName)
+		owner := newKeystoreSigner(t, requireAccount(t, 3), "hunter2")
+
+		// when
+		_, err := anvil.DeployContract(t.Context(), contractName, owner)
+
+		// then
+		require.NoError(t, err)
+		args := readForgeArgs(t, dir)
+		require.Equal(t, []string{foundry.KeystoreFlag, owner.Path(), foundry.PasswordFileFlag}, args[4:7])
+		require.NotContains(t, args, "hunter2")
+		require.NoFileExists(t, args[7])
+
+		password, err := os.ReadFile(filepath.Join(dir, "password"))
+		require.NoError(t, err)
+		require.Equal(t, "hunter2", string(password))
+	})
+
+	t.Run("happy path - impersonated", func(t *testing.T) {
+		// given
+		fake := startFakeRPC(t, map[string]any{
+			foundry.ImpersonateAccountMethod:       nil,
+			foundry.StopImpersonatingAccountMethod: nil,
+		})
+		installFakeAnvil(t, "exec sleep 30")
+		anvil, dir := newScriptAnvil(t, fakeForge, contractName, foundry.WithPort(fake.port))
+		require.NoError(t, anvil.Start(t.Context(), true))
+		t.Cleanup(func() { _ = anvil.Stop() })
+
+		owner, err := foundry.NewAccount(abandonPrivateKey)
+		require.NoError(t, err)
+		address := strconv.Quote(strings.ToLower(owner.Address().Hex()))
+
+		// when
+		_, err = anvil.DeployContract(t.Context(), contractName, owner)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []string{foundry.UnlockedFlag, foundry.SenderFlag, owner.Address().Hex()}, readForgeArgs(t, dir)[4:7])
+		requireParams(t, fake.lastCall(t, foundry.ImpersonateAccountMethod), address)
+		requireParams(t, fake.lastCall(t, foundry.StopImpersonatingAccountMethod), address)
+	})
+
+	t.Run("error - clef signer", func(t *testing.T) {
+		// given
+		anvil, dir := newScriptAnvil(t, fakeForge, contractName)
+		account := requireAccount(t, 4)
+		owner, err := foundry.NewClefSigner(startClefStandIn(t, account, nil), account.Address())
+		require.NoError(t, err)
+
+		// when
+		_, err = anvil.DeployContract(t.Context(), contractName, owner)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvilSigner)
+		require.ErrorContains(t, err, account.Address().Hex())
+		require.NoFileExists(t, filepath.Join(dir, "args"))
+	})
+
+	t.Run("error - forge fails", func(t *testing.T) {
+		// given
+		anvil, _ := newScriptAnvil(t, "echo 'script failed' >&2; exit 1", contractName)
+
+		// when
+		_, err := anvil.DeployContract(t.Context(), contractName, anvil.Account(0))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrAnvil)
+		require.ErrorContains(t, err, "script failed")
+	})
+
+	t.Run("error - contract not found", func(t *testing.T) {
+		// given
+		anvil, _ := newScriptAnvil(t, fakeForge, "HelloWorld")
+
+		// when
+		_, err := anvil.DeployContract(t.Context(), "HelloWorld", anvil.Account(0))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrContractNotFound)
+	})
+}
+
+// newScriptAnvil returns an Anvil whose `forge` is a shell script running
+// body, with the broadcast fixture already in place for scriptContract. It
+// also returns the directory the fake forge records its inputs in.
+func newScriptAnvil(
+	t *testing.T,
+	body string,
+	scriptContract string,
+	opts ...foundry.AnvilOption,
+) (*foundry.Anvil, string) {
+	t.Helper()
+	installFakeCommand(t, foundry.ForgeCommand, body)
+
+	dir := t.TempDir()
+	t.Setenv("FAKE_FORGE_DIR", dir)
+
+	broadcast := filepath.Join(dir, "broadcast")
+	path := filepath.Join(broadcast, scriptContract+".s.sol", "31337", "run-latest.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, broadcastJSON, 0o600))
+
+	anvil, err := foundry.NewAnvil(broadcast, scriptDir, opts...)
+	require.NoError(t, err)
+	return anvil, dir
+}
+
+func readForgeArgs(t *testing.T, dir string) []string {
+	t.Helper()
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(args), "\n"), "\n")
+}
@@ -0,0 +1,119 @@
+package foundry
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrSigner        = errors.New("signer")
+	ErrSignerAddress = fmt.Errorf("%w: address not managed by signer", ErrSigner)
+)
+
+// Signer signs transactions on behalf of one address.
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// NewTransactor returns the options abigen bindings need to send
+// transactions signed by signer on the chain chainID.
+func NewTransactor(signer Signer, chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, fmt.Errorf("%w: no chain ID", ErrSigner)
+	}
+
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, fmt.Errorf("%w: %s", ErrSignerAddress, address.Hex())
+			}
+			return signer.SignTx(tx, chainID)
+		},
+	}, nil
+}
+
+// SignTx signs tx with the account's private key.
+func (a *Account) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), a.privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signing transaction: %w", ErrSigner, err)
+	}
+	return signed, nil
+}
+
+// KeystoreSigner signs with the key of a passphrase-protected go-ethereum
+// keystore file, like the ones `cast wallet import` writes.
+type KeystoreSigner struct {
+	path       string
+	passphrase string
+	account    *Account
+}
+
+// NewKeystoreSigner decrypts the keystore file at path with passphrase.
+func NewKeystoreSigner(path string, passphrase string) (*KeystoreSigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading keystore: %w", ErrSigner, err)
+	}
+
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decrypting keystore: %w", ErrSigner, err)
+	}
+
+	return &KeystoreSigner{
+		path:       path,
+		passphrase: passphrase,
+		account:    newAccount(key.PrivateKey),
+	}, nil
+}
+
+func (s *KeystoreSigner) Address() common.Address { return s.account.Address() }
+func (s *KeystoreSigner) Path() string            { return s.path }
+
+func (s *KeystoreSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.account.SignTx(tx, chainID)
+}
+
+// ClefSigner signs through an external signer speaking Clef's `account_*`
+// JSON-RPC API. The key never leaves the external signer.
+type ClefSigner struct {
+	address common.Address
+	signer  *external.ExternalSigner
+}
+
+// NewClefSigner connects to the external signer at endpoint, an HTTP URL or
+// IPC path, and checks that it manages address.
+func NewClefSigner(endpoint string, address common.Address) (*ClefSigner, error) {
+	signer, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: connecting to %s: %w", ErrSigner, endpoint, err)
+	}
+
+	if !signer.Contains(accounts.Account{Address: address}) {
+		return nil, fmt.Errorf("%w: %s", ErrSignerAddress, address.Hex())
+	}
+	return &ClefSigner{address: address, signer: signer}, nil
+}
+
+func (s *ClefSigner) Address() common.Address { return s.address }
+
+func (s *ClefSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := s.signer.SignTx(accounts.Account{Address: s.address}, tx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signing transaction: %w", ErrSigner, err)
+	}
+	return signed, nil
+}
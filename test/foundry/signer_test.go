@@ -0,0 +1,260 @@
+package foundry_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+var errRequestDenied = errors.New("request denied")
+
+func TestNewTransactor(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		tx := newDynamicFeeTx(chainID)
+
+		// when
+		opts, err := foundry.NewTransactor(account, chainID)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, account.Address(), opts.From)
+		signed, err := opts.Signer(account.Address(), tx)
+		require.NoError(t, err)
+		requireSender(t, account.Address(), signed)
+	})
+
+	t.Run("error - other address", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+		chainID := big.NewInt(foundry.ChainID)
+		opts, err := foundry.NewTransactor(account, chainID)
+		require.NoError(t, err)
+
+		// when
+		_, err = opts.Signer(requireAccount(t, 1).Address(), newDynamicFeeTx(chainID))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSignerAddress)
+	})
+
+	t.Run("error - no chain ID", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 0)
+
+		// when
+		_, err := foundry.NewTransactor(account, nil)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSigner)
+	})
+}
+
+func TestNewKeystoreSigner(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 2)
+		chainID := big.NewInt(foundry.ChainID)
+		path := writeKeystore(t, account, "hunter2")
+
+		// when
+		signer, err := foundry.NewKeystoreSigner(path, "hunter2")
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, account.Address(), signer.Address())
+		require.Equal(t, path, signer.Path())
+		signed, err := signer.SignTx(newDynamicFeeTx(chainID), chainID)
+		require.NoError(t, err)
+		requireSender(t, account.Address(), signed)
+	})
+
+	t.Run("error - wrong passphrase", func(t *testing.T) {
+		// given
+		path := writeKeystore(t, requireAccount(t, 2), "hunter2")
+
+		// when
+		_, err := foundry.NewKeystoreSigner(path, "hunter3")
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSigner)
+		require.ErrorIs(t, err, keystore.ErrDecrypt)
+	})
+
+	t.Run("error - not found", func(t *testing.T) {
+		// given
+		path := filepath.Join(t.TempDir(), "missing.json")
+
+		// when
+		_, err := foundry.NewKeystoreSigner(path, "hunter2")
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSigner)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestNewClefSigner(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 4)
+		chainID := big.NewInt(foundry.ChainID)
+		endpoint := startClefStandIn(t, account, nil)
+
+		// when
+		signer, err := foundry.NewClefSigner(endpoint, account.Address())
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, account.Address(), signer.Address())
+		signed, err := signer.SignTx(newDynamicFeeTx(chainID), chainID)
+		require.NoError(t, err)
+		requireSender(t, account.Address(), signed)
+	})
+
+	t.Run("error - address not managed", func(t *testing.T) {
+		// given
+		endpoint := startClefStandIn(t, requireAccount(t, 4), nil)
+
+		// when
+		_, err := foundry.NewClefSigner(endpoint, requireAccount(t, 5).Address())
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSignerAddress)
+	})
+
+	t.Run("error - signing denied", func(t *testing.T) {
+		// given
+		account := requireAccount(t, 4)
+		chainID := big.NewInt(foundry.ChainID)
+		endpoint := startClefStandIn(t, account, errRequestDenied)
+		signer, err := foundry.NewClefSigner(endpoint, account.Address())
+		require.NoError(t, err)
+
+		// when
+		_, err = signer.SignTx(newDynamicFeeTx(chainID), chainID)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSigner)
+		require.ErrorContains(t, err, errRequestDenied.Error())
+	})
+
+	t.Run("error - unreachable", func(t *testing.T) {
+		// given
+		endpoint := "http://127.0.0.1:1"
+
+		// when
+		_, err := foundry.NewClefSigner(endpoint, requireAccount(t, 4).Address())
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSigner)
+	})
+}
+
+// clefStandIn serves the part of Clef's external API that go-ethereum's
+// external signer uses, approving every request unless told to deny them.
+type clefStandIn struct {
+	account *foundry.Account
+	deny    error
+}
+
+func (c *clefStandIn) Version() string { return "6.0.0" }
+
+func (c *clefStandIn) List() []common.Address { return []common.Address{c.account.Address()} }
+
+func (c *clefStandIn) SignTransaction(
+	_ context.Context,
+	args apitypes.SendTxArgs,
+	_ *string,
+) (map[string]any, error) {
+	if c.deny != nil {
+		return nil, c.deny
+	}
+
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := c.account.SignTx(tx, (*big.Int)(args.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+}
+
+// startClefStandIn serves a clefStandIn holding account and returns its URL.
+func startClefStandIn(t *testing.T, account *foundry.Account, deny error) string {
+	t.Helper()
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("account", &clefStandIn{account: account, deny: deny}))
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL
+}
+
+// newKeystoreSigner writes account to a keystore and opens it.
+func newKeystoreSigner(t *testing.T, account *foundry.Account, passphrase string) *foundry.KeystoreSigner {
+	t.Helper()
+	signer, err := foundry.NewKeystoreSigner(writeKeystore(t, account, passphrase), passphrase)
+	require.NoError(t, err)
+	return signer
+}
+
+func newDynamicFeeTx(chainID *big.Int) *types.Transaction {
+	to := common.Address{0x01}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(3),
+	})
+}
+
+func requireSender(t *testing.T, want common.Address, tx *types.Transaction) {
+	t.Helper()
+	got, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+// writeKeystore encrypts account with passphrase into a keystore file, with
+// the light scrypt parameters to keep tests fast.
+func writeKeystore(t *testing.T, account *foundry.Account, passphrase string) string {
+	t.Helper()
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    account.Address(),
+		PrivateKey: account.PrivateKey(),
+	}
+	data, err := keystore.EncryptKey(key, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
@@ -0,0 +1,84 @@
+package foundry
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	JumpInto    JumpType = "i"
+	JumpOutOf   JumpType = "o"
+	JumpRegular JumpType = "-"
+)
+
+// The fields of a source map entry, in order.
+const (
+	sourceMapStart = iota
+	sourceMapLength
+	sourceMapFile
+	sourceMapJump
+	sourceMapModifierDepth
+)
+
+var (
+	ErrSourceMap = errors.New("source map")
+)
+
+// JumpType tells whether an instruction jumps into a function, out of one,
+// or neither.
+type JumpType string
+
+// SourceMapEntry maps one instruction of the bytecode to the source range it
+// was compiled from. File is the index of the source file, or -1 for code
+// the compiler generated.
+type SourceMapEntry struct {
+	Start         int
+	Length        int
+	File          int
+	Jump          JumpType
+	ModifierDepth int
+}
+
+// ParseSourceMap decompresses a solc source map, in which each entry only
+// lists the fields that differ from the previous entry.
+//
+// Example: "26:487:0:-:0;;;;;;;;;;;;;;;;;;;74:6:0:i"
+func ParseSourceMap(sourceMap string) ([]SourceMapEntry, error) {
+	entries := []SourceMapEntry{}
+	if sourceMap == "" {
+		return entries, nil
+	}
+
+	current := SourceMapEntry{Jump: JumpRegular}
+	for i, entry := range strings.Split(sourceMap, ";") {
+		fields := strings.Split(entry, ":")
+		for j, field := range fields {
+			if field == "" {
+				continue
+			}
+
+			var err error
+			switch j {
+			case sourceMapStart:
+				current.Start, err = strconv.Atoi(field)
+			case sourceMapLength:
+				current.Length, err = strconv.Atoi(field)
+			case sourceMapFile:
+				current.File, err = strconv.Atoi(field)
+			case sourceMapJump:
+				current.Jump = JumpType(field)
+			case sourceMapModifierDepth:
+				current.ModifierDepth, err = strconv.Atoi(field)
+			default:
+				err = fmt.Errorf("%w: too many fields", ErrSourceMap)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: entry %d: %w", ErrSourceMap, i, err)
+			}
+		}
+		entries = append(entries, current)
+	}
+	return entries, nil
+}
@@ -0,0 +1,52 @@
+package foundry_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+func TestParseSourceMap(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		sourceMap := "26:487:0:-:0;;74:6::i;:1;-1:-1:-1:o"
+		want := []foundry.SourceMapEntry{
+			{Start: 26, Length: 487, File: 0, Jump: foundry.JumpRegular},
+			{Start: 26, Length: 487, File: 0, Jump: foundry.JumpRegular},
+			{Start: 74, Length: 6, File: 0, Jump: foundry.JumpInto},
+			{Start: 74, Length: 1, File: 0, Jump: foundry.JumpInto},
+			{Start: -1, Length: -1, File: -1, Jump: foundry.JumpOutOf},
+		}
+
+		// when
+		got, err := foundry.ParseSourceMap(sourceMap)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	})
+
+	t.Run("happy path - empty", func(t *testing.T) {
+		// given
+		sourceMap := ""
+
+		// when
+		got, err := foundry.ParseSourceMap(sourceMap)
+
+		// then
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+
+	t.Run("error - too many fields", func(t *testing.T) {
+		// given
+		sourceMap := "1:2:0:-:0:9"
+
+		// when
+		_, err := foundry.ParseSourceMap(sourceMap)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrSourceMap)
+	})
+}
@@ -4,43 +4,169 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+const (
+	TransactionTypeCall    = "CALL"
+	TransactionTypeCreate  = "CREATE"
+	TransactionTypeCreate2 = "CREATE2"
 )
 
 var (
-	ErrInner       = errors.New("inner")
-	ErrTransaction = errors.New("transaction")
+	ErrAdditionalContract = errors.New("additional contract")
+	ErrConversion         = errors.New("conversion")
+	ErrInner              = errors.New("inner")
+	ErrTransaction        = errors.New("transaction")
 )
 
+// AdditionalContract is a contract created during a broadcast transaction
+// other than its direct target, e.g. by a factory.
+type AdditionalContract struct {
+	TransactionType string
+	ContractName    string
+	Address         common.Address
+	InitCode        []byte
+}
+
+//nolint:tagliatelle
+type additionalContractJSON struct {
+	TransactionType string         `json:"transactionType"`
+	ContractName    string         `json:"contractName,omitempty"`
+	Address         common.Address `json:"address"`
+	InitCode        string         `json:"initCode"`
+}
+
+func (a *AdditionalContract) MarshalJSON() ([]byte, error) {
+	return json.Marshal(additionalContractJSON{
+		TransactionType: a.TransactionType,
+		ContractName:    a.ContractName,
+		Address:         a.Address,
+		InitCode:        BytesToHexString(a.InitCode),
+	})
+}
+
+func (a *AdditionalContract) UnmarshalJSON(data []byte) error {
+	additional := additionalContractJSON{}
+	err := json.Unmarshal(data, &additional)
+	if err != nil {
+		return fmt.Errorf("%w: unmarshaling additional contract: %w", ErrAdditionalContract, err)
+	}
+
+	initCode, err := ParseBytesFromHexString(additional.InitCode)
+	if err != nil {
+		return fmt.Errorf("%w: parsing init code: %w", ErrAdditionalContract, err)
+	}
+
+	a.TransactionType = additional.TransactionType
+	a.ContractName = additional.ContractName
+	a.Address = additional.Address
+	a.InitCode = initCode
+	return nil
+}
+
+// InnerTransaction is the transaction request forge broadcast. Legacy
+// transactions set GasPrice, EIP-1559 ones MaxFeePerGas and
+// MaxPriorityFeePerGas; the remaining optional fields belong to EIP-2930,
+// EIP-4844 and EIP-7702 transactions. Type is nil when forge left it for the
+// node to infer.
 type InnerTransaction struct {
-	From    *common.Address
-	Gas     uint64
-	Value   uint64
-	Input   []byte
-	Nonce   uint64
-	ChainID uint64
+	Type                 *uint64
+	From                 *common.Address
+	To                   *common.Address
+	Gas                  uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerBlobGas     *big.Int
+	Value                *big.Int
+	Input                []byte
+	Nonce                uint64
+	ChainID              uint64
+	AccessList           types.AccessList
+	BlobVersionedHashes  []common.Hash
+	AuthorizationList    []types.SetCodeAuthorization
 }
 
 //nolint:tagliatelle
 type innerJSON struct {
-	From    *common.Address `json:"from"`
-	Gas     string          `json:"gas"`
-	Value   string          `json:"value"`
-	Input   string          `json:"input"`
-	Nonce   string          `json:"nonce"`
-	ChainID string          `json:"chainId"`
+	Type                 string                       `json:"type,omitempty"`
+	From                 *common.Address              `json:"from"`
+	To                   *common.Address              `json:"to,omitempty"`
+	Gas                  string                       `json:"gas"`
+	GasPrice             string                       `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string                       `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string                       `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerBlobGas     string                       `json:"maxFeePerBlobGas,omitempty"`
+	Value                string                       `json:"value"`
+	Input                string                       `json:"input"`
+	Nonce                string                       `json:"nonce"`
+	ChainID              string                       `json:"chainId"`
+	AccessList           types.AccessList             `json:"accessList,omitempty"`
+	BlobVersionedHashes  []common.Hash                `json:"blobVersionedHashes,omitempty"`
+	AuthorizationList    []types.SetCodeAuthorization `json:"authorizationList,omitempty"`
+}
+
+// FromGeth sets i to the go-ethereum transaction sent by from. The chain ID
+// of a legacy transaction is only known once it is signed with EIP-155.
+func (i *InnerTransaction) FromGeth(tx *types.Transaction, from *common.Address) {
+	txType := uint64(tx.Type())
+	i.Type = &txType
+	i.From = from
+	i.To = tx.To()
+	i.Gas = tx.Gas()
+	i.GasPrice = nil
+	i.MaxFeePerGas = nil
+	i.MaxPriorityFeePerGas = nil
+	i.MaxFeePerBlobGas = nil
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		i.GasPrice = tx.GasPrice()
+	default:
+		i.MaxFeePerGas = tx.GasFeeCap()
+		i.MaxPriorityFeePerGas = tx.GasTipCap()
+	}
+	if tx.Type() == types.BlobTxType {
+		i.MaxFeePerBlobGas = tx.BlobGasFeeCap()
+	}
+	i.Value = tx.Value()
+	i.Input = tx.Data()
+	i.Nonce = tx.Nonce()
+	i.ChainID = 0
+	if tx.Protected() {
+		i.ChainID = tx.ChainId().Uint64()
+	}
+	i.AccessList = tx.AccessList()
+	i.BlobVersionedHashes = tx.BlobHashes()
+	i.AuthorizationList = tx.SetCodeAuthorizations()
 }
 
 func (i *InnerTransaction) MarshalJSON() ([]byte, error) {
-	return json.Marshal(innerJSON{
-		From:    i.From,
-		Gas:     Uint64ToHexString(i.Gas),
-		Value:   Uint64ToHexString(i.Value),
-		Input:   BytesToHexString(i.Input),
-		Nonce:   Uint64ToHexString(i.Nonce),
-		ChainID: Uint64ToHexString(i.ChainID),
-	})
+	inner := innerJSON{
+		From:                 i.From,
+		To:                   i.To,
+		Gas:                  Uint64ToHexString(i.Gas),
+		GasPrice:             optionalBigToHexString(i.GasPrice),
+		MaxFeePerGas:         optionalBigToHexString(i.MaxFeePerGas),
+		MaxPriorityFeePerGas: optionalBigToHexString(i.MaxPriorityFeePerGas),
+		MaxFeePerBlobGas:     optionalBigToHexString(i.MaxFeePerBlobGas),
+		Value:                BigToHexString(i.Value),
+		Input:                BytesToHexString(i.Input),
+		Nonce:                Uint64ToHexString(i.Nonce),
+		ChainID:              Uint64ToHexString(i.ChainID),
+		AccessList:           i.AccessList,
+		BlobVersionedHashes:  i.BlobVersionedHashes,
+		AuthorizationList:    i.AuthorizationList,
+	}
+	if i.Type != nil {
+		inner.Type = Uint64ToHexString(*i.Type)
+	}
+	return json.Marshal(inner)
 }
 
 func (i *InnerTransaction) UnmarshalJSON(data []byte) error {
@@ -50,12 +176,41 @@ func (i *InnerTransaction) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: unmarshaling inner: %w", ErrInner, err)
 	}
 
+	var txType *uint64
+	if inner.Type != "" {
+		parsed, err := ParseUint64FromHexString(inner.Type)
+		if err != nil {
+			return fmt.Errorf("%w: parsing type: %w", ErrInner, err)
+		}
+		txType = &parsed
+	}
+
 	gas, err := ParseUint64FromHexString(inner.Gas)
 	if err != nil {
 		return fmt.Errorf("%w: parsing gas: %w", ErrInner, err)
 	}
 
-	value, err := ParseUint64FromHexString(inner.Value)
+	gasPrice, err := parseOptionalBigFromHexString(inner.GasPrice)
+	if err != nil {
+		return fmt.Errorf("%w: parsing gas price: %w", ErrInner, err)
+	}
+
+	maxFeePerGas, err := parseOptionalBigFromHexString(inner.MaxFeePerGas)
+	if err != nil {
+		return fmt.Errorf("%w: parsing max fee per gas: %w", ErrInner, err)
+	}
+
+	maxPriorityFeePerGas, err := parseOptionalBigFromHexString(inner.MaxPriorityFeePerGas)
+	if err != nil {
+		return fmt.Errorf("%w: parsing max priority fee per gas: %w", ErrInner, err)
+	}
+
+	maxFeePerBlobGas, err := parseOptionalBigFromHexString(inner.MaxFeePerBlobGas)
+	if err != nil {
+		return fmt.Errorf("%w: parsing max fee per blob gas: %w", ErrInner, err)
+	}
+
+	value, err := ParseBigFromHexString(inner.Value)
 	if err != nil {
 		return fmt.Errorf("%w: parsing value: %w", ErrInner, err)
 	}
@@ -75,42 +230,236 @@ func (i *InnerTransaction) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: parsing chain ID: %w", ErrInner, err)
 	}
 
+	i.Type = txType
 	i.From = inner.From
+	i.To = inner.To
 	i.Gas = gas
+	i.GasPrice = gasPrice
+	i.MaxFeePerGas = maxFeePerGas
+	i.MaxPriorityFeePerGas = maxPriorityFeePerGas
+	i.MaxFeePerBlobGas = maxFeePerBlobGas
 	i.Value = value
 	i.Input = input
 	i.Nonce = nonce
 	i.ChainID = chainID
+	i.AccessList = inner.AccessList
+	i.BlobVersionedHashes = inner.BlobVersionedHashes
+	i.AuthorizationList = inner.AuthorizationList
 	return nil
 }
 
+// ToGeth returns the unsigned go-ethereum transaction described by i, ready
+// to be signed with types.SignTx. When forge left Type unset, it is inferred
+// from the fields present, as a node would.
+func (i *InnerTransaction) ToGeth() (*types.Transaction, error) {
+	chainID := new(big.Int).SetUint64(i.ChainID)
+	value := orZero(i.Value)
+
+	txType := i.txType()
+	switch txType {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    i.Nonce,
+			GasPrice: orZero(i.GasPrice),
+			Gas:      i.Gas,
+			To:       i.To,
+			Value:    value,
+			Data:     i.Input,
+		}), nil
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      i.Nonce,
+			GasPrice:   orZero(i.GasPrice),
+			Gas:        i.Gas,
+			To:         i.To,
+			Value:      value,
+			Data:       i.Input,
+			AccessList: i.AccessList,
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      i.Nonce,
+			GasTipCap:  orZero(i.MaxPriorityFeePerGas),
+			GasFeeCap:  orZero(i.MaxFeePerGas),
+			Gas:        i.Gas,
+			To:         i.To,
+			Value:      value,
+			Data:       i.Input,
+			AccessList: i.AccessList,
+		}), nil
+	case types.BlobTxType, types.SetCodeTxType:
+		return i.toGethUint256(txType)
+	default:
+		return nil, fmt.Errorf("%w: unsupported transaction type %d", ErrConversion, txType)
+	}
+}
+
+// toGethUint256 builds the transaction types whose amounts are 256-bit
+// integers and which cannot create contracts.
+func (i *InnerTransaction) toGethUint256(txType uint64) (*types.Transaction, error) {
+	if i.To == nil {
+		return nil, fmt.Errorf("%w: type %d transaction without recipient", ErrConversion, txType)
+	}
+
+	amounts := map[string]*big.Int{
+		"max priority fee per gas": i.MaxPriorityFeePerGas,
+		"max fee per gas":          i.MaxFeePerGas,
+		"max fee per blob gas":     i.MaxFeePerBlobGas,
+		"value":                    i.Value,
+	}
+	converted := make(map[string]*uint256.Int, len(amounts))
+	for name, amount := range amounts {
+		n, overflow := uint256.FromBig(orZero(amount))
+		if overflow {
+			return nil, fmt.Errorf("%w: %s overflows 256 bits", ErrConversion, name)
+		}
+		converted[name] = n
+	}
+
+	if txType == types.BlobTxType {
+		return types.NewTx(&types.BlobTx{
+			ChainID:    uint256.NewInt(i.ChainID),
+			Nonce:      i.Nonce,
+			GasTipCap:  converted["max priority fee per gas"],
+			GasFeeCap:  converted["max fee per gas"],
+			Gas:        i.Gas,
+			To:         *i.To,
+			Value:      converted["value"],
+			Data:       i.Input,
+			AccessList: i.AccessList,
+			BlobFeeCap: converted["max fee per blob gas"],
+			BlobHashes: i.BlobVersionedHashes,
+		}), nil
+	}
+	return types.NewTx(&types.SetCodeTx{
+		ChainID:    uint256.NewInt(i.ChainID),
+		Nonce:      i.Nonce,
+		GasTipCap:  converted["max priority fee per gas"],
+		GasFeeCap:  converted["max fee per gas"],
+		Gas:        i.Gas,
+		To:         *i.To,
+		Value:      converted["value"],
+		Data:       i.Input,
+		AccessList: i.AccessList,
+		AuthList:   i.AuthorizationList,
+	}), nil
+}
+
+func (i *InnerTransaction) txType() uint64 {
+	switch {
+	case i.Type != nil:
+		return *i.Type
+	case len(i.AuthorizationList) > 0:
+		return types.SetCodeTxType
+	case len(i.BlobVersionedHashes) > 0:
+		return types.BlobTxType
+	case i.MaxFeePerGas != nil || i.MaxPriorityFeePerGas != nil:
+		return types.DynamicFeeTxType
+	case len(i.AccessList) > 0:
+		return types.AccessListTxType
+	case i.GasPrice != nil:
+		return types.LegacyTxType
+	default:
+		return types.DynamicFeeTxType
+	}
+}
+
+func optionalBigToHexString(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return BigToHexString(n)
+}
+
+func orZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
+
+func parseOptionalBigFromHexString(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil //nolint:nilnil
+	}
+	return ParseBigFromHexString(s)
+}
+
 type Transaction struct {
-	Hash            []byte
-	TransactionType string
-	ContractName    string
-	ContractAddress *common.Address
-	Inner           *InnerTransaction
-	IsFixedGasLimit bool
+	Hash                common.Hash
+	TransactionType     string
+	ContractName        string
+	ContractAddress     *common.Address
+	Function            *string
+	Arguments           []string
+	Inner               *InnerTransaction
+	AdditionalContracts []*AdditionalContract
+	IsFixedGasLimit     bool
 }
 
 //nolint:tagliatelle
 type transactionJSON struct {
-	Hash            string            `json:"hash"`
-	TransactionType string            `json:"transactionType"`
-	ContractName    string            `json:"contractName"`
-	ContractAddress *common.Address   `json:"contractAddress"`
-	Inner           *InnerTransaction `json:"transaction"`
-	IsFixedGasLimit bool              `json:"isFixedGasLimit"`
+	Hash                common.Hash           `json:"hash"`
+	TransactionType     string                `json:"transactionType"`
+	ContractName        string                `json:"contractName"`
+	ContractAddress     *common.Address       `json:"contractAddress"`
+	Function            *string               `json:"function"`
+	Arguments           []string              `json:"arguments"`
+	Inner               *InnerTransaction     `json:"transaction"`
+	AdditionalContracts []*AdditionalContract `json:"additionalContracts"`
+	IsFixedGasLimit     bool                  `json:"isFixedGasLimit"`
+}
+
+// FromGeth sets t to a signed go-ethereum transaction. The contract name and
+// the other fields forge fills from the script's artifacts are cleared.
+func (t *Transaction) FromGeth(tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("%w: recovering sender: %w", ErrConversion, err)
+	}
+
+	transactionType := TransactionTypeCall
+	contractAddress := tx.To()
+	if contractAddress == nil {
+		transactionType = TransactionTypeCreate
+		created := crypto.CreateAddress(from, tx.Nonce())
+		contractAddress = &created
+	}
+
+	inner := &InnerTransaction{}
+	inner.FromGeth(tx, &from)
+
+	t.Hash = tx.Hash()
+	t.TransactionType = transactionType
+	t.ContractName = ""
+	t.ContractAddress = contractAddress
+	t.Function = nil
+	t.Arguments = nil
+	t.Inner = inner
+	t.AdditionalContracts = nil
+	t.IsFixedGasLimit = false
+	return nil
+}
+
+// IsCreate reports whether the transaction deployed a contract.
+func (t *Transaction) IsCreate() bool {
+	return t.TransactionType == TransactionTypeCreate ||
+		t.TransactionType == TransactionTypeCreate2
 }
 
 func (t *Transaction) MarshalJSON() ([]byte, error) {
 	return json.Marshal(transactionJSON{
-		Hash:            BytesToHexString(t.Hash),
-		TransactionType: t.TransactionType,
-		ContractName:    t.ContractName,
-		ContractAddress: t.ContractAddress,
-		Inner:           t.Inner,
-		IsFixedGasLimit: t.IsFixedGasLimit,
+		Hash:                t.Hash,
+		TransactionType:     t.TransactionType,
+		ContractName:        t.ContractName,
+		ContractAddress:     t.ContractAddress,
+		Function:            t.Function,
+		Arguments:           t.Arguments,
+		Inner:               t.Inner,
+		AdditionalContracts: t.AdditionalContracts,
+		IsFixedGasLimit:     t.IsFixedGasLimit,
 	})
 }
 
@@ -121,16 +470,23 @@ func (t *Transaction) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("%w: unmarshaling transaction: %w", ErrTransaction, err)
 	}
 
-	hash, err := ParseBytesFromHexString(transaction.Hash)
-	if err != nil {
-		return fmt.Errorf("%w: parsing hash: %w", ErrTransaction, err)
-	}
-
-	t.Hash = hash
+	t.Hash = transaction.Hash
 	t.TransactionType = transaction.TransactionType
 	t.ContractName = transaction.ContractName
 	t.ContractAddress = transaction.ContractAddress
+	t.Function = transaction.Function
+	t.Arguments = transaction.Arguments
 	t.Inner = transaction.Inner
+	t.AdditionalContracts = transaction.AdditionalContracts
 	t.IsFixedGasLimit = transaction.IsFixedGasLimit
 	return nil
 }
+
+// ToGeth returns the unsigned go-ethereum transaction forge broadcast. See
+// InnerTransaction.ToGeth.
+func (t *Transaction) ToGeth() (*types.Transaction, error) {
+	if t.Inner == nil {
+		return nil, fmt.Errorf("%w: transaction %s has no inner transaction", ErrConversion, t.Hash)
+	}
+	return t.Inner.ToGeth()
+}
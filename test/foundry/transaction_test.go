@@ -2,8 +2,11 @@ package foundry_test
 
 import (
 	_ "embed"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tahardi/bearchain/test/foundry"
 )
@@ -11,6 +14,21 @@ import (
 //go:embed testdata/inner.json
 var innerJSON []byte
 
+//go:embed testdata/inner-legacy.json
+var innerLegacyJSON []byte
+
+//go:embed testdata/inner-1559.json
+var inner1559JSON []byte
+
+//go:embed testdata/inner-4844.json
+var inner4844JSON []byte
+
+//go:embed testdata/inner-7702.json
+var inner7702JSON []byte
+
+//go:embed testdata/inner-large-value.json
+var innerLargeValueJSON []byte
+
 //go:embed testdata/transaction.json
 var transactionJSON []byte
 
@@ -30,6 +48,122 @@ func TestInner_JSON(t *testing.T) {
 		require.NoError(t, err)
 		require.JSONEq(t, string(want), string(got))
 	})
+
+	t.Run("happy path - round trip transaction types", func(t *testing.T) {
+		for name, want := range map[string][]byte{
+			"legacy":   innerLegacyJSON,
+			"eip-1559": inner1559JSON,
+			"eip-4844": inner4844JSON,
+			"eip-7702": inner7702JSON,
+		} {
+			t.Run(name, func(t *testing.T) {
+				// when
+				inner := &foundry.InnerTransaction{}
+				err := inner.UnmarshalJSON(want)
+				require.NoError(t, err)
+
+				got, err := inner.MarshalJSON()
+
+				// then
+				require.NoError(t, err)
+				require.JSONEq(t, string(want), string(got))
+			})
+		}
+	})
+
+	t.Run("happy path - legacy", func(t *testing.T) {
+		// given
+		inner := &foundry.InnerTransaction{}
+
+		// when
+		err := inner.UnmarshalJSON(innerLegacyJSON)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint64(types.LegacyTxType), *inner.Type)
+		require.Equal(t, common.HexToAddress("0x5fbdb2315678afecb367f032d93f642f64180aa3"), *inner.To)
+		require.Equal(t, big.NewInt(2_000_000_000), inner.GasPrice)
+		require.Nil(t, inner.MaxFeePerGas)
+		require.Nil(t, inner.MaxPriorityFeePerGas)
+	})
+
+	t.Run("happy path - eip-1559 access list", func(t *testing.T) {
+		// given
+		inner := &foundry.InnerTransaction{}
+
+		// when
+		err := inner.UnmarshalJSON(inner1559JSON)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint64(types.DynamicFeeTxType), *inner.Type)
+		require.Equal(t, big.NewInt(2_000_000_001), inner.MaxFeePerGas)
+		require.Equal(t, big.NewInt(1), inner.MaxPriorityFeePerGas)
+		require.Nil(t, inner.GasPrice)
+		require.Len(t, inner.AccessList, 1)
+		require.Len(t, inner.AccessList[0].StorageKeys, 2)
+	})
+
+	t.Run("happy path - eip-7702 authorization", func(t *testing.T) {
+		// given
+		inner := &foundry.InnerTransaction{}
+		owner := requireAccount(t, 0)
+
+		// when
+		err := inner.UnmarshalJSON(inner7702JSON)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint64(types.SetCodeTxType), *inner.Type)
+		require.Len(t, inner.AuthorizationList, 1)
+
+		authority, err := inner.AuthorizationList[0].Authority()
+		require.NoError(t, err)
+		require.Equal(t, owner.Address(), authority)
+	})
+
+	t.Run("happy path - type left to the node", func(t *testing.T) {
+		// given
+		inner := &foundry.InnerTransaction{}
+
+		// when
+		err := inner.UnmarshalJSON(innerJSON)
+
+		// then
+		require.NoError(t, err)
+		require.Nil(t, inner.Type)
+		require.Nil(t, inner.To)
+	})
+
+	t.Run("happy path - value above 64 bits", func(t *testing.T) {
+		// given
+		want := innerLargeValueJSON
+		value, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+		// when
+		inner := &foundry.InnerTransaction{}
+		err := inner.UnmarshalJSON(want)
+		require.NoError(t, err)
+
+		got, err := inner.MarshalJSON()
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, value, inner.Value)
+		require.JSONEq(t, string(want), string(got))
+	})
+
+	t.Run("error - invalid value", func(t *testing.T) {
+		// given
+		data := []byte(`{"gas":"0x0","value":"0xzz","input":"0x","nonce":"0x0","chainId":"0x7a69"}`)
+
+		// when
+		err := (&foundry.InnerTransaction{}).UnmarshalJSON(data)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrInner)
+		require.ErrorIs(t, err, foundry.ErrParse)
+	})
 }
 
 func TestTransaction_JSON(t *testing.T) {
@@ -49,3 +183,126 @@ func TestTransaction_JSON(t *testing.T) {
 		require.JSONEq(t, string(want), string(got))
 	})
 }
+
+func TestInner_Geth(t *testing.T) {
+	t.Run("happy path - round trip transaction types", func(t *testing.T) {
+		owner := requireAccount(t, 0)
+		signer := types.LatestSignerForChainID(big.NewInt(foundry.ChainID))
+		for name, want := range map[string][]byte{
+			"legacy":   innerLegacyJSON,
+			"eip-1559": inner1559JSON,
+			"eip-4844": inner4844JSON,
+			"eip-7702": inner7702JSON,
+		} {
+			t.Run(name, func(t *testing.T) {
+				// given
+				inner := &foundry.InnerTransaction{}
+				require.NoError(t, inner.UnmarshalJSON(want))
+
+				// when
+				unsigned, err := inner.ToGeth()
+				require.NoError(t, err)
+
+				tx, err := types.SignTx(unsigned, signer, owner.PrivateKey())
+				require.NoError(t, err)
+
+				got := &foundry.InnerTransaction{}
+				got.FromGeth(tx, inner.From)
+
+				// then
+				require.Equal(t, *inner.Type, uint64(tx.Type()))
+				gotJSON, err := got.MarshalJSON()
+				require.NoError(t, err)
+				require.JSONEq(t, string(want), string(gotJSON))
+			})
+		}
+	})
+
+	t.Run("happy path - infers type", func(t *testing.T) {
+		// given
+		inner := &foundry.InnerTransaction{}
+		require.NoError(t, inner.UnmarshalJSON(innerJSON))
+
+		// when
+		tx, err := inner.ToGeth()
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+		require.Nil(t, tx.To())
+		require.Equal(t, inner.Input, tx.Data())
+	})
+
+	t.Run("error - set code without recipient", func(t *testing.T) {
+		// given
+		inner := &foundry.InnerTransaction{}
+		require.NoError(t, inner.UnmarshalJSON(inner7702JSON))
+		inner.To = nil
+
+		// when
+		_, err := inner.ToGeth()
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrConversion)
+	})
+
+	t.Run("error - unsupported type", func(t *testing.T) {
+		// given
+		txType := uint64(0x7e)
+		inner := &foundry.InnerTransaction{Type: &txType}
+
+		// when
+		_, err := inner.ToGeth()
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrConversion)
+	})
+}
+
+func TestTransaction_Geth(t *testing.T) {
+	t.Run("happy path - signed round trip", func(t *testing.T) {
+		// given
+		owner := requireAccount(t, 0)
+		broadcast := &foundry.Transaction{}
+		require.NoError(t, broadcast.UnmarshalJSON(transactionJSON))
+
+		unsigned, err := broadcast.ToGeth()
+		require.NoError(t, err)
+
+		signer := types.LatestSignerForChainID(big.NewInt(foundry.ChainID))
+		signed, err := types.SignTx(unsigned, signer, owner.PrivateKey())
+		require.NoError(t, err)
+
+		// when
+		got := &foundry.Transaction{}
+		err = got.FromGeth(signed)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, signed.Hash(), got.Hash)
+		require.Equal(t, foundry.TransactionTypeCreate, got.TransactionType)
+		require.Equal(t, broadcast.ContractAddress, got.ContractAddress)
+		require.Equal(t, owner.Address(), *got.Inner.From)
+		require.Equal(t, broadcast.Inner.Input, got.Inner.Input)
+		require.Equal(t, broadcast.Inner.Gas, got.Inner.Gas)
+	})
+
+	t.Run("error - unsigned", func(t *testing.T) {
+		// given
+		tx := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(foundry.ChainID)})
+
+		// when
+		err := (&foundry.Transaction{}).FromGeth(tx)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrConversion)
+	})
+
+	t.Run("error - no inner transaction", func(t *testing.T) {
+		// when
+		_, err := (&foundry.Transaction{}).ToGeth()
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrConversion)
+	})
+}
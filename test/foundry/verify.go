@@ -0,0 +1,281 @@
+package foundry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	MismatchTransactionMissing MismatchKind = "transaction missing"
+	MismatchStatus             MismatchKind = "status"
+	MismatchBlockHash          MismatchKind = "block hash"
+	MismatchGasUsed            MismatchKind = "gas used"
+	MismatchCodeMissing        MismatchKind = "code missing"
+	MismatchCode               MismatchKind = "code"
+)
+
+var (
+	ErrVerify = fmt.Errorf("%w: verification failed", ErrBroadcast)
+)
+
+// MismatchKind is what part of a broadcast disagrees with the chain.
+type MismatchKind string
+
+// Mismatch is a disagreement between a broadcast and the chain. Want is what
+// the broadcast recorded or the artifact compiled to, Got is what the chain
+// returned. Code is compared by its keccak256 hash.
+type Mismatch struct {
+	Kind            MismatchKind
+	TransactionHash common.Hash
+	ContractName    string
+	Address         *common.Address
+	Want            string
+	Got             string
+}
+
+func (m *Mismatch) String() string {
+	var subject string
+	switch {
+	case m.Address != nil && m.ContractName != "":
+		subject = fmt.Sprintf("contract %s at %s", m.ContractName, m.Address.Hex())
+	case m.Address != nil:
+		subject = "contract at " + m.Address.Hex()
+	default:
+		subject = "transaction " + m.TransactionHash.Hex()
+	}
+
+	if m.Want == "" && m.Got == "" {
+		return fmt.Sprintf("%s: %s", subject, m.Kind)
+	}
+	return fmt.Sprintf("%s: %s: want %s, got %s", subject, m.Kind, m.Want, m.Got)
+}
+
+// VerifyReport lists every mismatch found by Verify.
+type VerifyReport struct {
+	Mismatches []*Mismatch
+}
+
+// OK reports whether the broadcast matches the chain.
+func (r *VerifyReport) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// Err returns nil if the broadcast matches the chain, or an ErrVerify listing
+// every mismatch.
+func (r *VerifyReport) Err() error {
+	if r.OK() {
+		return nil
+	}
+
+	mismatches := make([]string, 0, len(r.Mismatches))
+	for _, mismatch := range r.Mismatches {
+		mismatches = append(mismatches, mismatch.String())
+	}
+	return fmt.Errorf("%w: %s", ErrVerify, strings.Join(mismatches, "; "))
+}
+
+// VerifyConfig holds what Verify checks beyond transactions and receipts.
+type VerifyConfig struct {
+	// OutDir is the `forge build` output directory holding the artifacts to
+	// compare deployed code against. Empty only checks that code exists.
+	OutDir string
+}
+
+func DefaultVerifyConfig() *VerifyConfig {
+	return &VerifyConfig{
+		OutDir: "",
+	}
+}
+
+// VerifyOption overrides a field of the VerifyConfig used by Verify.
+type VerifyOption func(*VerifyConfig)
+
+// WithArtifacts compares the code of every created contract with the
+// runtime bytecode of its artifact in outDir.
+func WithArtifacts(outDir string) VerifyOption {
+	return func(c *VerifyConfig) { c.OutDir = outDir }
+}
+
+// Verify checks the broadcast against the chain client is connected to, which
+// catches stale broadcast files and reorgs. Every transaction must be mined
+// with the recorded receipt's status, block hash and gas used, and every
+// created contract must have code. Transactions still pending when forge
+// exited may be missing. Mismatches are collected in the report;
+// the error is only set if the chain could not be queried.
+//
+// Artifacts are linked with the libraries the broadcast recorded before their
+// code is compared. Immutables are filled in by the constructor, so they are
+// ignored.
+func (b *Broadcast) Verify(
+	ctx context.Context,
+	client Backend,
+	opts ...VerifyOption,
+) (*VerifyReport, error) {
+	config := DefaultVerifyConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	report := &VerifyReport{Mismatches: []*Mismatch{}}
+	for _, tx := range b.Transactions {
+		err := b.verifyTransaction(ctx, client, report, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	libraries, err := b.libraryAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := map[string]*Artifact{}
+	for _, contract := range b.createdContracts() {
+		if slices.Contains(b.Pending, contract.tx.Hash) {
+			continue
+		}
+
+		code, err := client.CodeAt(ctx, contract.Address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: getting code at %s: %w", ErrBroadcast, contract.Address.Hex(), err)
+		}
+
+		address := contract.Address
+		if len(code) == 0 {
+			report.Mismatches = append(report.Mismatches, &Mismatch{
+				Kind:            MismatchCodeMissing,
+				TransactionHash: contract.tx.Hash,
+				ContractName:    contract.Name,
+				Address:         &address,
+			})
+			continue
+		}
+		if config.OutDir == "" || contract.Name == "" {
+			continue
+		}
+
+		artifact, ok := artifacts[contract.Name]
+		if !ok {
+			artifact, err = loadLinkedArtifact(config.OutDir, contract.Name, libraries)
+			if err != nil {
+				return nil, err
+			}
+			artifacts[contract.Name] = artifact
+		}
+
+		want := maskImmutables(artifact.DeployedBytecode, artifact.ImmutableReferences)
+		got := maskImmutables(code, artifact.ImmutableReferences)
+		if !bytes.Equal(want, got) {
+			report.Mismatches = append(report.Mismatches, &Mismatch{
+				Kind:            MismatchCode,
+				TransactionHash: contract.tx.Hash,
+				ContractName:    contract.Name,
+				Address:         &address,
+				Want:            crypto.Keccak256Hash(want).Hex(),
+				Got:             crypto.Keccak256Hash(got).Hex(),
+			})
+		}
+	}
+	return report, nil
+}
+
+func (b *Broadcast) verifyTransaction(
+	ctx context.Context,
+	client Backend,
+	report *VerifyReport,
+	tx *Transaction,
+) error {
+	onChain, err := client.TransactionReceipt(ctx, tx.Hash)
+	switch {
+	case errors.Is(err, ethereum.NotFound) && slices.Contains(b.Pending, tx.Hash):
+		return nil
+	case errors.Is(err, ethereum.NotFound):
+		report.Mismatches = append(report.Mismatches, &Mismatch{
+			Kind:            MismatchTransactionMissing,
+			TransactionHash: tx.Hash,
+		})
+		return nil
+	case err != nil:
+		return fmt.Errorf("%w: getting receipt of %s: %w", ErrBroadcast, tx.Hash.Hex(), err)
+	}
+
+	// A transaction that was pending when forge exited has no receipt to
+	// compare with.
+	recorded := b.receipt(tx.Hash)
+	if recorded == nil {
+		return nil
+	}
+
+	mismatch := func(kind MismatchKind, want string, got string) {
+		report.Mismatches = append(report.Mismatches, &Mismatch{
+			Kind:            kind,
+			TransactionHash: tx.Hash,
+			Want:            want,
+			Got:             got,
+		})
+	}
+	if recorded.Status != onChain.Status {
+		mismatch(
+			MismatchStatus,
+			strconv.FormatUint(recorded.Status, 10),
+			strconv.FormatUint(onChain.Status, 10),
+		)
+	}
+	if recorded.BlockHash != onChain.BlockHash {
+		mismatch(MismatchBlockHash, recorded.BlockHash.Hex(), onChain.BlockHash.Hex())
+	}
+	if recorded.GasUsed != onChain.GasUsed {
+		mismatch(
+			MismatchGasUsed,
+			strconv.FormatUint(recorded.GasUsed, 10),
+			strconv.FormatUint(onChain.GasUsed, 10),
+		)
+	}
+	return nil
+}
+
+// loadLinkedArtifact loads the artifact of contractName with the libraries
+// of the broadcast linked in, as they are in the deployed code.
+func loadLinkedArtifact(
+	outDir string,
+	contractName string,
+	libraries map[string]common.Address,
+) (*Artifact, error) {
+	artifact, err := LoadArtifact(outDir, contractName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBroadcast, err)
+	}
+	if len(artifact.LinkReferences) == 0 && len(artifact.DeployedLinkReferences) == 0 {
+		return artifact, nil
+	}
+
+	linked, err := artifact.Link(libraries)
+	if err != nil {
+		return nil, fmt.Errorf("%w: linking %s: %w", ErrBroadcast, contractName, err)
+	}
+	return linked, nil
+}
+
+// maskImmutables returns a copy of code with the given ranges zeroed, as they
+// are in the artifact's deployed bytecode.
+func maskImmutables(code []byte, references map[string][]BytecodeRange) []byte {
+	masked := bytes.Clone(code)
+	for _, ranges := range references {
+		for _, r := range ranges {
+			if r.Start < 0 || r.Length < 0 || r.Start+r.Length > len(masked) {
+				continue
+			}
+			clear(masked[r.Start : r.Start+r.Length])
+		}
+	}
+	return masked
+}
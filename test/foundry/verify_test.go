@@ -0,0 +1,355 @@
+package foundry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/mocks"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+var errConnectionRefused = errors.New("connection refused")
+
+func TestBroadcast_Verify(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+		artifact := unmarshalArtifact(t, artifactJSON)
+		outDir := writeArtifact(t, contractName, artifactJSON)
+
+		backend := mocks.NewBackend(t)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().
+			CodeAt(mock.Anything, common.HexToAddress(contractAddress), mock.Anything).
+			Return(artifact.DeployedBytecode, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(outDir))
+
+		// then
+		require.NoError(t, err)
+		require.True(t, got.OK())
+		require.NoError(t, got.Err())
+	})
+
+	t.Run("happy path - immutables ignored", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+		artifact := unmarshalArtifact(t, artifactJSON)
+		references := map[string][]foundry.BytecodeRange{"7": {{Start: 10, Length: 32}}}
+		outDir := writeArtifact(t, contractName, withImmutableReferences(t, references))
+
+		code := bytes.Clone(artifact.DeployedBytecode)
+		copy(code[10:42], bytes.Repeat([]byte{0xff}, 32))
+
+		backend := mocks.NewBackend(t)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().CodeAt(mock.Anything, mock.Anything, mock.Anything).Return(code, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(outDir))
+
+		// then
+		require.NoError(t, err)
+		require.True(t, got.OK())
+	})
+
+	t.Run("happy path - receipt mismatches", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+		hash := broadcast.Transactions[0].Hash
+
+		onChain := unmarshalBroadcast(t, broadcastJSON)
+		onChain.Receipts[0].Status = 0
+		onChain.Receipts[0].BlockHash = common.Hash{0x01}
+		onChain.Receipts[0].GasUsed++
+
+		backend := mocks.NewBackend(t)
+		expectReceipts(t, backend, onChain)
+		backend.EXPECT().CodeAt(mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x00}, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []*foundry.Mismatch{
+			{Kind: foundry.MismatchStatus, TransactionHash: hash, Want: "1", Got: "0"},
+			{
+				Kind:            foundry.MismatchBlockHash,
+				TransactionHash: hash,
+				Want:            broadcast.Receipts[0].BlockHash.Hex(),
+				Got:             common.Hash{0x01}.Hex(),
+			},
+			{Kind: foundry.MismatchGasUsed, TransactionHash: hash, Want: "1409780", Got: "1409781"},
+		}, got.Mismatches)
+		require.ErrorIs(t, got.Err(), foundry.ErrVerify)
+	})
+
+	t.Run("happy path - missing transaction and code", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+		address := common.HexToAddress(contractAddress)
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, mock.Anything).
+			Return(nil, ethereum.NotFound)
+		backend.EXPECT().CodeAt(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend)
+
+		// then
+		require.NoError(t, err)
+		require.Equal(t, []*foundry.Mismatch{
+			{
+				Kind:            foundry.MismatchTransactionMissing,
+				TransactionHash: broadcast.Transactions[0].Hash,
+			},
+			{
+				Kind:            foundry.MismatchCodeMissing,
+				TransactionHash: broadcast.Transactions[0].Hash,
+				ContractName:    contractName,
+				Address:         &address,
+			},
+		}, got.Mismatches)
+		require.ErrorContains(t, got.Err(), "contract BearCoin at "+address.Hex()+": code missing")
+	})
+
+	t.Run("happy path - code mismatch", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+		outDir := writeArtifact(t, contractName, artifactJSON)
+
+		backend := mocks.NewBackend(t)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().CodeAt(mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x00}, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(outDir))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got.Mismatches, 1)
+		require.Equal(t, foundry.MismatchCode, got.Mismatches[0].Kind)
+		require.Equal(t, contractName, got.Mismatches[0].ContractName)
+	})
+
+	t.Run("happy path - pending transactions skipped", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		pending := broadcast.Transactions[2].Hash
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, pending).
+			Return(nil, ethereum.NotFound)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().
+			CodeAt(mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{0x00}, nil).
+			Times(2)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend)
+
+		// then
+		require.NoError(t, err)
+		require.True(t, got.OK())
+	})
+
+	t.Run("happy path - linked library", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		factory, pair := requireFactoryArtifacts(t)
+		outDir := writeFactoryArtifacts(t)
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, broadcast.Transactions[2].Hash).
+			Return(nil, ethereum.NotFound)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().
+			CodeAt(mock.Anything, *broadcast.Transactions[0].ContractAddress, mock.Anything).
+			Return(factory.DeployedBytecode, nil)
+		backend.EXPECT().
+			CodeAt(mock.Anything, broadcast.Transactions[1].AdditionalContracts[0].Address, mock.Anything).
+			Return(pair.DeployedBytecode, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(outDir))
+
+		// then
+		require.NoError(t, err)
+		require.True(t, got.OK(), got.Err())
+	})
+
+	t.Run("happy path - library linked at another address", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		broadcast.Libraries = []string{"src/libraries/PairMath.sol:PairMath:0x0000000000000000000000000000000000000001"}
+		factory, pair := requireFactoryArtifacts(t)
+		outDir := writeFactoryArtifacts(t)
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, broadcast.Transactions[2].Hash).
+			Return(nil, ethereum.NotFound)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().
+			CodeAt(mock.Anything, *broadcast.Transactions[0].ContractAddress, mock.Anything).
+			Return(factory.DeployedBytecode, nil)
+		backend.EXPECT().
+			CodeAt(mock.Anything, broadcast.Transactions[1].AdditionalContracts[0].Address, mock.Anything).
+			Return(pair.DeployedBytecode, nil)
+
+		// when
+		got, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(outDir))
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, got.Mismatches, 1)
+		require.Equal(t, foundry.MismatchCode, got.Mismatches[0].Kind)
+		require.Equal(t, "Pair", got.Mismatches[0].ContractName)
+	})
+
+	t.Run("error - client fails", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, mock.Anything).
+			Return(nil, errConnectionRefused)
+
+		// when
+		_, err := broadcast.Verify(t.Context(), backend)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrBroadcast)
+		require.ErrorIs(t, err, errConnectionRefused)
+	})
+
+	t.Run("error - library not recorded", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		broadcast.Libraries = nil
+		factory, _ := requireFactoryArtifacts(t)
+		outDir := writeFactoryArtifacts(t)
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, broadcast.Transactions[2].Hash).
+			Return(nil, ethereum.NotFound)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().
+			CodeAt(mock.Anything, *broadcast.Transactions[0].ContractAddress, mock.Anything).
+			Return(factory.DeployedBytecode, nil)
+		backend.EXPECT().
+			CodeAt(mock.Anything, broadcast.Transactions[1].AdditionalContracts[0].Address, mock.Anything).
+			Return([]byte{0x00}, nil)
+
+		// when
+		_, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(outDir))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrBroadcast)
+		require.ErrorIs(t, err, foundry.ErrArtifactUnlinked)
+	})
+
+	t.Run("error - invalid library", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, factoryBroadcastJSON)
+		broadcast.Libraries = []string{"src/libraries/PairMath.sol:PairMath"}
+
+		backend := mocks.NewBackend(t)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, broadcast.Transactions[2].Hash).
+			Return(nil, ethereum.NotFound)
+		expectReceipts(t, backend, broadcast)
+
+		// when
+		_, err := broadcast.Verify(t.Context(), backend)
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrBroadcast)
+		require.ErrorContains(t, err, "invalid library")
+	})
+
+	t.Run("error - artifact not found", func(t *testing.T) {
+		// given
+		broadcast := unmarshalBroadcast(t, broadcastJSON)
+
+		backend := mocks.NewBackend(t)
+		expectReceipts(t, backend, broadcast)
+		backend.EXPECT().CodeAt(mock.Anything, mock.Anything, mock.Anything).Return([]byte{0x00}, nil)
+
+		// when
+		_, err := broadcast.Verify(t.Context(), backend, foundry.WithArtifacts(t.TempDir()))
+
+		// then
+		require.ErrorIs(t, err, foundry.ErrArtifact)
+	})
+}
+
+// expectReceipts makes backend return every receipt of broadcast.
+func expectReceipts(t *testing.T, backend *mocks.Backend, broadcast *foundry.Broadcast) {
+	t.Helper()
+	for _, receipt := range broadcast.Receipts {
+		gethReceipt, err := receipt.ToGeth()
+		require.NoError(t, err)
+		backend.EXPECT().
+			TransactionReceipt(mock.Anything, receipt.TransactionHash).
+			Return(gethReceipt, nil)
+	}
+}
+
+// requireFactoryArtifacts returns the artifacts of the contracts the factory
+// broadcast creates, with PairMath linked in at the address it records.
+func requireFactoryArtifacts(t *testing.T) (*foundry.Artifact, *foundry.Artifact) {
+	t.Helper()
+	pair, err := unmarshalArtifact(t, linkedArtifactJSON).Link(map[string]common.Address{
+		"src/libraries/PairMath.sol:PairMath": common.HexToAddress("0x5fbdb2315678afecb367f032d93f642f64180aa3"),
+	})
+	require.NoError(t, err)
+	return unmarshalArtifact(t, artifactJSON), pair
+}
+
+// writeFactoryArtifacts lays out the artifacts of the factory broadcast and
+// returns their out directory.
+func writeFactoryArtifacts(t *testing.T) string {
+	t.Helper()
+	outDir := t.TempDir()
+	writeArtifactIn(t, outDir, "Factory.sol", "PairFactory", artifactJSON)
+	return writeArtifactIn(t, outDir, "Factory.sol", "Pair", linkedArtifactJSON)
+}
+
+func unmarshalArtifact(t *testing.T, data []byte) *foundry.Artifact {
+	t.Helper()
+	artifact := &foundry.Artifact{}
+	require.NoError(t, json.Unmarshal(data, artifact))
+	return artifact
+}
+
+// withImmutableReferences returns the artifact fixture with the given
+// immutable references.
+func withImmutableReferences(t *testing.T, references map[string][]foundry.BytecodeRange) []byte {
+	t.Helper()
+	fixture := map[string]any{}
+	require.NoError(t, json.Unmarshal(artifactJSON, &fixture))
+
+	deployed, ok := fixture["deployedBytecode"].(map[string]any)
+	require.True(t, ok)
+	deployed["immutableReferences"] = references
+
+	data, err := json.Marshal(fixture)
+	require.NoError(t, err)
+	return data
+}
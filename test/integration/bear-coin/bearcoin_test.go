@@ -2,11 +2,15 @@ package bearcoin_test
 
 import (
 	"math/big"
+	"sync"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
 	"github.com/tahardi/bearchain/test/integration"
 )
 
@@ -19,11 +23,9 @@ const (
 func TestBearCoin_Allowance(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		// when/then
 		requireAllowance(t, contract, owner, other, nil)
@@ -34,33 +36,29 @@ func TestBearCoin_Allowance(t *testing.T) {
 func TestBearCoin_Approve(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireAllowance(t, contract, owner, other, nil)
 
 		// when
-		_, err := approve(t, anvil, contract, owner, other, amount)
+		receipt, err := approve(t, anvil, contract, owner, other, amount)
 
 		// then
 		require.NoError(t, err)
 		requireAllowance(t, contract, owner, other, amount)
+		requireApprovalEvent(t, contract, receipt, owner, other, amount)
 	})
 }
 
 func TestBearCoin_BalanceOf(t *testing.T) {
 	t.Run("happy path - owner", func(t *testing.T) {
 		// given
+		anvil := shared.Isolate(t)
 		want := totalSupply()
-
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
 		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		// when
 		got, err := contract.BalanceOf(nil, owner.Address())
@@ -72,11 +70,8 @@ func TestBearCoin_BalanceOf(t *testing.T) {
 
 	t.Run("happy path - other", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
-		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		anvil := shared.Isolate(t)
+		contract := newBearCoin(t, anvil)
 
 		other := anvil.Account(1)
 
@@ -92,30 +87,28 @@ func TestBearCoin_BalanceOf(t *testing.T) {
 func TestBearCoin_Burn(t *testing.T) {
 	t.Run("happy path - owner burn", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		burnAmount := big.NewInt(100)
 		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 
 		// when
-		_, err := burn(t, anvil, contract, owner, burnAmount)
+		receipt, err := burn(t, anvil, contract, owner, burnAmount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, owner, totalSupply().Sub(totalSupply(), burnAmount))
+		requireTransferEvent(t, contract, receipt, owner.Address(), common.Address{}, burnAmount)
+		requireBurnEvent(t, contract, receipt, owner, burnAmount)
 	})
 
 	t.Run("happy path - other burn", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		_, err := burn(t, anvil, contract, owner, amount)
 		require.NoError(t, err)
@@ -125,37 +118,41 @@ func TestBearCoin_Burn(t *testing.T) {
 		requireBalance(t, contract, other, amount)
 
 		// when
-		_, err = burn(t, anvil, contract, other, amount)
+		receipt, err := burn(t, anvil, contract, other, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, other, nil)
+		requireTransferEvent(t, contract, receipt, other.Address(), common.Address{}, amount)
+		requireBurnEvent(t, contract, receipt, other, amount)
 	})
 
 	t.Run("error - burn amount greater than supply", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		burnAmount := totalSupply().Add(totalSupply(), big.NewInt(1))
 		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 
 		// when
 		_, err := burn(t, anvil, contract, owner, burnAmount)
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(
+			t,
+			err,
+			"ERC20InsufficientBalance",
+			owner.Address(),
+			totalSupply(),
+			burnAmount,
+		)
 	})
 
 	t.Run("error - user has no tokens to burn", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
-		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		anvil := shared.Isolate(t)
+		contract := newBearCoin(t, anvil)
 
 		burnAmount := totalSupply()
 		brokeUser := anvil.Account(1)
@@ -165,19 +162,24 @@ func TestBearCoin_Burn(t *testing.T) {
 		_, err := burn(t, anvil, contract, brokeUser, burnAmount)
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(
+			t,
+			err,
+			"ERC20InsufficientBalance",
+			brokeUser.Address(),
+			big.NewInt(0),
+			burnAmount,
+		)
 	})
 }
 
 func TestBearCoin_Mint(t *testing.T) {
 	t.Run("happy path - owner mint-to-self", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 
 		_, err := burn(t, anvil, contract, owner, amount)
@@ -185,21 +187,21 @@ func TestBearCoin_Mint(t *testing.T) {
 		requireBalance(t, contract, owner, totalSupply().Sub(totalSupply(), amount))
 
 		// when
-		_, err = mint(t, anvil, contract, owner, owner, amount)
+		receipt, err := mint(t, anvil, contract, owner, owner, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, owner, totalSupply())
+		requireTransferEvent(t, contract, receipt, common.Address{}, owner.Address(), amount)
+		requireMintEvent(t, contract, receipt, owner, amount)
 	})
 
 	t.Run("happy path - owner mint-to-other", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 
 		_, err := burn(t, anvil, contract, owner, amount)
@@ -208,39 +210,36 @@ func TestBearCoin_Mint(t *testing.T) {
 		requireBalance(t, contract, other, nil)
 
 		// when
-		_, err = mint(t, anvil, contract, owner, other, amount)
+		receipt, err := mint(t, anvil, contract, owner, other, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, other, amount)
+		requireTransferEvent(t, contract, receipt, common.Address{}, other.Address(), amount)
+		requireMintEvent(t, contract, receipt, other, amount)
 	})
 
 	t.Run("error - only owner can mint", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
-		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		other := anvil.Account(1)
+		contract := newBearCoin(t, anvil)
 
 		// when
 		_, err := mint(t, anvil, contract, other, other, amount)
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(t, err, foundry.RevertError, "Not owner")
 	})
 }
 
 func TestBearCoin_Name(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		want := "BearCoin"
-		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		// when
 		got, err := contract.Name(nil)
@@ -254,12 +253,9 @@ func TestBearCoin_Name(t *testing.T) {
 func TestBearCoin_Symbol(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		want := "BCN"
-		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		// when
 		got, err := contract.Symbol(nil)
@@ -273,12 +269,9 @@ func TestBearCoin_Symbol(t *testing.T) {
 func TestBearCoin_TotalSupply(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		want := totalSupply()
-		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		// when
 		got, err := contract.TotalSupply(nil)
@@ -292,50 +285,83 @@ func TestBearCoin_TotalSupply(t *testing.T) {
 func TestBearCoin_Transfer(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, other, nil)
 
 		// when
-		_, err := transfer(t, anvil, contract, owner, other, amount)
+		receipt, err := transfer(t, anvil, contract, owner, other, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, owner, totalSupply().Sub(totalSupply(), amount))
 		requireBalance(t, contract, other, amount)
+		requireTransferEvent(t, contract, receipt, owner.Address(), other.Address(), amount)
 	})
 
-	t.Run("happy path - transfer to self", func(t *testing.T) {
+	t.Run("happy path - concurrent transfers", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
+		const transfers = 10
+		anvil := shared.Isolate(t)
+		amount := big.NewInt(100)
+		owner, other := anvil.Account(0), anvil.Account(1)
+		contract := newBearCoin(t, anvil)
+		nonces := integration.NewNonceManager(t, anvil)
+		executor := integration.NewExecutor(t, anvil, foundry.WithNonceManager(nonces))
+		requireBalance(t, contract, owner, totalSupply())
+		requireBalance(t, contract, other, nil)
+
+		// when
+		errs := make([]error, transfers)
+		var wg sync.WaitGroup
+		for i := range errs {
+			wg.Go(func() {
+				_, errs[i] = executor.Execute(
+					t.Context(),
+					owner,
+					func(opts *bind.TransactOpts) (*types.Transaction, error) {
+						return contract.Transfer(opts, other.Address(), amount)
+					},
+				)
+			})
+		}
+		wg.Wait()
+
+		// then
+		for _, err := range errs {
+			require.NoError(t, err)
+		}
+		sent := new(big.Int).Mul(amount, big.NewInt(transfers))
+		requireBalance(t, contract, owner, totalSupply().Sub(totalSupply(), sent))
+		requireBalance(t, contract, other, sent)
+	})
 
+	t.Run("happy path - transfer to self", func(t *testing.T) {
+		// given
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 
 		// when
-		_, err := transfer(t, anvil, contract, owner, owner, amount)
+		receipt, err := transfer(t, anvil, contract, owner, owner, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, owner, totalSupply())
+		requireTransferEvent(t, contract, receipt, owner.Address(), owner.Address(), amount)
 	})
 
 	t.Run("error - insufficient funds", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, other := anvil.Account(0), anvil.Account(1)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, other, nil)
 
@@ -343,7 +369,14 @@ func TestBearCoin_Transfer(t *testing.T) {
 		_, err := transfer(t, anvil, contract, other, owner, amount)
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(
+			t,
+			err,
+			"ERC20InsufficientBalance",
+			other.Address(),
+			big.NewInt(0),
+			amount,
+		)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, other, nil)
 	})
@@ -352,12 +385,10 @@ func TestBearCoin_Transfer(t *testing.T) {
 func TestBearCoin_TransferFrom(t *testing.T) {
 	t.Run("happy path", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, alice, bob := anvil.Account(0), anvil.Account(1), anvil.Account(2)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, bob, nil)
 
@@ -366,24 +397,24 @@ func TestBearCoin_TransferFrom(t *testing.T) {
 		requireAllowance(t, contract, owner, alice, amount)
 
 		// when
-		_, err = transferFrom(t, anvil, contract, owner, alice, bob, amount)
+		receipt, err := transferFrom(t, anvil, contract, owner, alice, bob, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, owner, totalSupply().Sub(totalSupply(), amount))
 		requireBalance(t, contract, bob, amount)
 		requireAllowance(t, contract, owner, alice, nil)
+		requireTransferEvent(t, contract, receipt, owner.Address(), bob.Address(), amount)
+		integration.RequireNoEvent(t, receipt, contractAddress, contract.ParseApproval)
 	})
 
 	t.Run("happy path - unlimited allowance", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		unlimited := requireMaxUint256(t)
 		amount := big.NewInt(100)
 		owner, alice, bob := anvil.Account(0), anvil.Account(1), anvil.Account(2)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, bob, nil)
 
@@ -392,23 +423,23 @@ func TestBearCoin_TransferFrom(t *testing.T) {
 		requireAllowance(t, contract, owner, alice, unlimited)
 
 		// when
-		_, err = transferFrom(t, anvil, contract, owner, alice, bob, amount)
+		receipt, err := transferFrom(t, anvil, contract, owner, alice, bob, amount)
 
 		// then
 		require.NoError(t, err)
 		requireBalance(t, contract, owner, totalSupply().Sub(totalSupply(), amount))
 		requireBalance(t, contract, bob, amount)
 		requireAllowance(t, contract, owner, alice, unlimited)
+		requireTransferEvent(t, contract, receipt, owner.Address(), bob.Address(), amount)
+		integration.RequireNoEvent(t, receipt, contractAddress, contract.ParseApproval)
 	})
 
 	t.Run("error - insufficient allowance", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		amount := big.NewInt(100)
 		owner, alice, bob := anvil.Account(0), anvil.Account(1), anvil.Account(2)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, bob, nil)
 		requireAllowance(t, contract, owner, alice, nil)
@@ -417,7 +448,14 @@ func TestBearCoin_TransferFrom(t *testing.T) {
 		_, err := transferFrom(t, anvil, contract, owner, alice, bob, amount)
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(
+			t,
+			err,
+			"ERC20InsufficientAllowance",
+			alice.Address(),
+			big.NewInt(0),
+			amount,
+		)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, bob, nil)
 		requireAllowance(t, contract, owner, alice, nil)
@@ -425,12 +463,10 @@ func TestBearCoin_TransferFrom(t *testing.T) {
 
 	t.Run("error - insufficient funds", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		insufficient, amount := big.NewInt(100), big.NewInt(200)
 		owner, alice, bob := anvil.Account(0), anvil.Account(1), anvil.Account(2)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, bob, nil)
 
@@ -442,7 +478,14 @@ func TestBearCoin_TransferFrom(t *testing.T) {
 		_, err = transferFrom(t, anvil, contract, owner, alice, bob, amount)
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(
+			t,
+			err,
+			"ERC20InsufficientAllowance",
+			alice.Address(),
+			insufficient,
+			amount,
+		)
 		requireBalance(t, contract, owner, totalSupply())
 		requireBalance(t, contract, bob, nil)
 		requireAllowance(t, contract, owner, alice, insufficient)
@@ -452,11 +495,9 @@ func TestBearCoin_TransferFrom(t *testing.T) {
 func TestBearCoin_TransferOwnership(t *testing.T) {
 	t.Run("happy path - deployer is owner", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		want := anvil.Account(0)
-		contract := deployContract(t, anvil, want)
+		contract := newBearCoin(t, anvil)
 
 		// when
 		got, err := contract.Owner(nil)
@@ -468,27 +509,24 @@ func TestBearCoin_TransferOwnership(t *testing.T) {
 
 	t.Run("happy path - transfer ownership", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		oldOwner := anvil.Account(0)
-		contract := deployContract(t, anvil, oldOwner)
+		contract := newBearCoin(t, anvil)
 
 		got, err := contract.Owner(nil)
 		require.NoError(t, err)
 		integration.AssertAddressesEqual(t, oldOwner.Address(), got)
 
 		newOwner := anvil.Account(1)
-		opts := newTransactionOpts(t, anvil, oldOwner)
-		call := func() (*types.Transaction, error) {
-			return contract.TransferOwnership(opts, newOwner.Address())
-		}
 
 		// when
-		_, err = executeCall(t, anvil, call)
+		receipt, err := execute(t, anvil, oldOwner, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.TransferOwnership(opts, newOwner.Address())
+		})
 
 		// then
 		require.NoError(t, err)
+		integration.RequireNoEvents(t, receipt)
 		got, err = contract.Owner(nil)
 		require.NoError(t, err)
 		integration.AssertAddressesEqual(t, newOwner.Address(), got)
@@ -496,26 +534,22 @@ func TestBearCoin_TransferOwnership(t *testing.T) {
 
 	t.Run("error - other cannot transfer Ownership", func(t *testing.T) {
 		// given
-		anvil, stop := integration.StartAnvil(t, true)
-		defer stop()
-
+		anvil := shared.Isolate(t)
 		owner := anvil.Account(0)
-		contract := deployContract(t, anvil, owner)
+		contract := newBearCoin(t, anvil)
 
 		got, err := contract.Owner(nil)
 		require.NoError(t, err)
 		integration.AssertAddressesEqual(t, owner.Address(), got)
 
 		other := anvil.Account(1)
-		opts := newTransactionOpts(t, anvil, other)
-		call := func() (*types.Transaction, error) {
-			return contract.TransferOwnership(opts, other.Address())
-		}
 
 		// when
-		_, err = executeCall(t, anvil, call)
+		_, err = execute(t, anvil, other, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.TransferOwnership(opts, other.Address())
+		})
 
 		// then
-		require.Error(t, err)
+		integration.RequireRevertWith(t, err, foundry.RevertError, "Not owner")
 	})
 }
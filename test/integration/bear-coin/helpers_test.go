@@ -5,10 +5,12 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tahardi/bearchain/contracts/bindings"
 	"github.com/tahardi/bearchain/test/foundry"
+	"github.com/tahardi/bearchain/test/integration"
 )
 
 func approve(
@@ -20,61 +22,48 @@ func approve(
 	amount *big.Int,
 ) (*types.Receipt, error) {
 	t.Helper()
-	opts := newTransactionOpts(t, anvil, principal)
-	call := func() (*types.Transaction, error) {
+	return execute(t, anvil, principal, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		return contract.Approve(opts, proxy.Address(), amount)
-	}
-	return executeCall(t, anvil, call)
+	})
 }
 
-func burn(
+func bindBearCoin(
 	t *testing.T,
 	anvil *foundry.Anvil,
-	contract *bindings.BearCoin,
-	account *foundry.Account,
-	amount *big.Int,
-) (*types.Receipt, error) {
-	t.Helper()
-	opts := newTransactionOpts(t, anvil, account)
-	call := func() (*types.Transaction, error) {
-		return contract.Burn(opts, amount)
-	}
-	return executeCall(t, anvil, call)
-}
-
-func deployContract(
-	t *testing.T,
-	anvil *foundry.Anvil,
-	owner *foundry.Account,
+	address common.Address,
 ) *bindings.BearCoin {
 	t.Helper()
-	contractAddress, err := anvil.DeployContract(t.Context(), ContractName, owner)
-	require.NoError(t, err)
-
 	client, err := anvil.Client()
 	require.NoError(t, err)
 
-	contract, err := bindings.NewBearCoin(*contractAddress, client)
+	contract, err := bindings.NewBearCoin(address, client)
 	require.NoError(t, err)
 	return contract
 }
 
-func executeCall(
+func burn(
 	t *testing.T,
 	anvil *foundry.Anvil,
-	contractCall func() (*types.Transaction, error),
+	contract *bindings.BearCoin,
+	account *foundry.Account,
+	amount *big.Int,
 ) (*types.Receipt, error) {
 	t.Helper()
-	tx, err := contractCall()
-	if err != nil {
-		return nil, err
-	}
+	return execute(t, anvil, account, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.Burn(opts, amount)
+	})
+}
 
-	client, err := anvil.Client()
-	if err != nil {
-		return nil, err
-	}
-	return bind.WaitMined(t.Context(), client, tx)
+// execute sends the transaction transact builds from the given account and
+// waits for it to be mined.
+func execute(
+	t *testing.T,
+	anvil *foundry.Anvil,
+	from foundry.Signer,
+	transact foundry.TransactFunc,
+) (*types.Receipt, error) {
+	t.Helper()
+	return integration.NewExecutor(t, anvil).Execute(t.Context(), from, transact)
 }
 
 func mint(
@@ -86,22 +75,18 @@ func mint(
 	amount *big.Int,
 ) (*types.Receipt, error) {
 	t.Helper()
-	opts := newTransactionOpts(t, anvil, owner)
-	call := func() (*types.Transaction, error) {
+	return execute(t, anvil, owner, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		return contract.Mint(opts, to.Address(), amount)
-	}
-	return executeCall(t, anvil, call)
+	})
 }
 
-func newTransactionOpts(
+// newBearCoin binds to the BearCoin deployed by the TestMain fixture.
+func newBearCoin(
 	t *testing.T,
 	anvil *foundry.Anvil,
-	from *foundry.Account,
-) *bind.TransactOpts {
+) *bindings.BearCoin {
 	t.Helper()
-	opts, err := bind.NewKeyedTransactorWithChainID(from.PrivateKey(), anvil.ChainID())
-	require.NoError(t, err)
-	return opts
+	return bindBearCoin(t, anvil, contractAddress)
 }
 
 func requireAllowance(
@@ -121,6 +106,23 @@ func requireAllowance(
 	}
 }
 
+// requireApprovalEvent requires receipt to have exactly one Approval event
+// from the TestMain BearCoin, for the given allowance.
+func requireApprovalEvent(
+	t *testing.T,
+	contract *bindings.BearCoin,
+	receipt *types.Receipt,
+	principal *foundry.Account,
+	proxy *foundry.Account,
+	amount *big.Int,
+) {
+	t.Helper()
+	event := integration.RequireEvent(t, receipt, contractAddress, contract.ParseApproval)
+	require.Equal(t, principal.Address(), event.Owner)
+	require.Equal(t, proxy.Address(), event.Spender)
+	require.Equal(t, 0, amount.Cmp(event.Value))
+}
+
 func requireBalance(
 	t *testing.T,
 	contract *bindings.BearCoin,
@@ -137,6 +139,21 @@ func requireBalance(
 	}
 }
 
+// requireBurnEvent requires receipt to have exactly one Burn event from the
+// TestMain BearCoin.
+func requireBurnEvent(
+	t *testing.T,
+	contract *bindings.BearCoin,
+	receipt *types.Receipt,
+	from *foundry.Account,
+	amount *big.Int,
+) {
+	t.Helper()
+	event := integration.RequireEvent(t, receipt, contractAddress, contract.ParseBurn)
+	require.Equal(t, from.Address(), event.From)
+	require.Equal(t, 0, amount.Cmp(event.Amount))
+}
+
 func requireMaxUint256(t *testing.T) *big.Int {
 	t.Helper()
 	maxUint256, ok := new(big.Int).
@@ -148,6 +165,39 @@ func requireMaxUint256(t *testing.T) *big.Int {
 	return maxUint256
 }
 
+// requireMintEvent requires receipt to have exactly one Mint event from the
+// TestMain BearCoin.
+func requireMintEvent(
+	t *testing.T,
+	contract *bindings.BearCoin,
+	receipt *types.Receipt,
+	to *foundry.Account,
+	amount *big.Int,
+) {
+	t.Helper()
+	event := integration.RequireEvent(t, receipt, contractAddress, contract.ParseMint)
+	require.Equal(t, to.Address(), event.To)
+	require.Equal(t, 0, amount.Cmp(event.Amount))
+}
+
+// requireTransferEvent requires receipt to have exactly one Transfer event
+// from the TestMain BearCoin. Mints are transfers from, and burns transfers
+// to, the zero address.
+func requireTransferEvent(
+	t *testing.T,
+	contract *bindings.BearCoin,
+	receipt *types.Receipt,
+	from common.Address,
+	to common.Address,
+	amount *big.Int,
+) {
+	t.Helper()
+	event := integration.RequireEvent(t, receipt, contractAddress, contract.ParseTransfer)
+	require.Equal(t, from, event.From)
+	require.Equal(t, to, event.To)
+	require.Equal(t, 0, amount.Cmp(event.Value))
+}
+
 func totalSupply() *big.Int {
 	decimals := big.NewInt(Decimals)
 	base := big.NewInt(Base)
@@ -165,11 +215,9 @@ func transfer(
 	amount *big.Int,
 ) (*types.Receipt, error) {
 	t.Helper()
-	opts := newTransactionOpts(t, anvil, from)
-	call := func() (*types.Transaction, error) {
+	return execute(t, anvil, from, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		return contract.Transfer(opts, to.Address(), amount)
-	}
-	return executeCall(t, anvil, call)
+	})
 }
 
 func transferFrom(
@@ -182,9 +230,7 @@ func transferFrom(
 	amount *big.Int,
 ) (*types.Receipt, error) {
 	t.Helper()
-	opts := newTransactionOpts(t, anvil, proxy)
-	call := func() (*types.Transaction, error) {
+	return execute(t, anvil, proxy, func(opts *bind.TransactOpts) (*types.Transaction, error) {
 		return contract.TransferFrom(opts, principal.Address(), to.Address(), amount)
-	}
-	return executeCall(t, anvil, call)
+	})
 }
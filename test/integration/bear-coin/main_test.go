@@ -0,0 +1,47 @@
+package bearcoin_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/tahardi/bearchain/test/foundry"
+	"github.com/tahardi/bearchain/test/integration"
+)
+
+var (
+	shared          *integration.SharedAnvil
+	contractAddress common.Address
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	shared, err = integration.StartSharedAnvil(context.Background(), true, deployBearCoin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "starting shared anvil: %s\n", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	_ = shared.Stop()
+	os.Exit(code)
+}
+
+// deployBearCoin deploys the BearCoin every test starts from, owned by the
+// first Anvil account.
+func deployBearCoin(ctx context.Context, anvil *foundry.Anvil) error {
+	client, err := anvil.Client()
+	if err != nil {
+		return err
+	}
+
+	deployer := foundry.NewDeployer(integration.OutDir, client, anvil.ChainID())
+	deployed, err := deployer.Deploy(ctx, ContractName, anvil.Account(0))
+	if err != nil {
+		return err
+	}
+	contractAddress = deployed.Address
+	return nil
+}
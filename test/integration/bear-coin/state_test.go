@@ -0,0 +1,69 @@
+package bearcoin_test
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+	"github.com/tahardi/bearchain/test/integration"
+)
+
+// TestBearCoin_LoadState starts its own Anvils rather than isolating the
+// shared one, so its subtests run in parallel.
+func TestBearCoin_LoadState(t *testing.T) {
+	t.Run("happy path - fixture", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		anvil, stop := integration.StartAnvil(
+			t,
+			true,
+			foundry.WithLoadState(integration.BearCoinStatePath),
+		)
+		defer stop()
+
+		share := new(big.Int).Div(totalSupply(), big.NewInt(int64(len(anvil.Accounts()))))
+
+		// when
+		contract := bindBearCoin(t, anvil, common.HexToAddress(integration.BearCoinStateAddress))
+
+		// then
+		for _, account := range anvil.Accounts() {
+			requireBalance(t, contract, account, share)
+		}
+		owner, err := contract.Owner(nil)
+		require.NoError(t, err)
+		integration.AssertAddressesEqual(t, anvil.Account(0).Address(), owner)
+	})
+
+	t.Run("happy path - dump and load", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		amount := big.NewInt(100)
+		path := filepath.Join(t.TempDir(), "state.json")
+
+		source, stopSource := integration.StartAnvil(t, true)
+		defer stopSource()
+
+		owner, other := source.Account(0), source.Account(1)
+		deployed, err := integration.NewDeployer(t, source).Deploy(t.Context(), ContractName, owner)
+		require.NoError(t, err)
+
+		_, err = transfer(t, source, bindBearCoin(t, source, deployed.Address), owner, other, amount)
+		require.NoError(t, err)
+
+		// when
+		err = source.DumpState(t.Context(), path)
+		require.NoError(t, err)
+
+		target, stopTarget := integration.StartAnvil(t, true, foundry.WithLoadState(path))
+		defer stopTarget()
+
+		// then
+		requireBalance(t, bindBearCoin(t, target, deployed.Address), other, amount)
+	})
+}
@@ -3,7 +3,9 @@ package integration
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tahardi/bearchain/test/foundry"
@@ -12,7 +14,15 @@ import (
 const (
 	ContractDir  = "../../../contracts"
 	BroadcastDir = ContractDir + "/broadcast"
+	OutDir       = ContractDir + "/out"
 	ScriptDir    = ContractDir + "/scripts"
+	TestdataDir  = "../testdata"
+
+	// BearCoinStatePath is a chain state with BearCoin deployed by the first
+	// default account and its supply split evenly across all ten. Regenerate
+	// it with `make state-bear-coin`.
+	BearCoinStatePath    = TestdataDir + "/bearcoin-state.json"
+	BearCoinStateAddress = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
 )
 
 func AssertAddressesEqual(
@@ -24,12 +34,126 @@ func AssertAddressesEqual(
 	assert.Equal(t, 0, address1.Cmp(address2))
 }
 
+// NewDeployer returns a deployer that creates contracts on anvil from the
+// artifacts in OutDir.
+func NewDeployer(
+	t *testing.T,
+	anvil *foundry.Anvil,
+) *foundry.Deployer {
+	t.Helper()
+	client, err := anvil.Client()
+	require.NoError(t, err)
+	return foundry.NewDeployer(OutDir, client, anvil.ChainID())
+}
+
+// NewExecutor returns an executor for transactions sent to anvil.
+func NewExecutor(
+	t *testing.T,
+	anvil *foundry.Anvil,
+	opts ...foundry.ExecutorOption,
+) *foundry.Executor {
+	t.Helper()
+	client, err := anvil.Client()
+	require.NoError(t, err)
+	return foundry.NewExecutor(client, anvil.ChainID(), opts...)
+}
+
+// NewNonceManager returns a nonce manager for transactions sent to anvil.
+func NewNonceManager(
+	t *testing.T,
+	anvil *foundry.Anvil,
+) *foundry.NonceManager {
+	t.Helper()
+	client, err := anvil.Client()
+	require.NoError(t, err)
+	return foundry.NewNonceManager(client)
+}
+
+// RequireEvent requires address to have emitted exactly one event parse
+// decodes in receipt, and returns it.
+func RequireEvent[T any](
+	t *testing.T,
+	receipt *types.Receipt,
+	address common.Address,
+	parse foundry.EventParser[T],
+) *T {
+	t.Helper()
+	events, err := foundry.Events(receipt, address, parse)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	return events[0]
+}
+
+// RequireNoEvent requires address to have emitted no event parse decodes in
+// receipt. Unlike RequireNoEvents, other logs are allowed.
+func RequireNoEvent[T any](
+	t *testing.T,
+	receipt *types.Receipt,
+	address common.Address,
+	parse foundry.EventParser[T],
+) {
+	t.Helper()
+	events, err := foundry.Events(receipt, address, parse)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+// RequireNoEvents requires receipt to have no logs.
+func RequireNoEvents(
+	t *testing.T,
+	receipt *types.Receipt,
+) {
+	t.Helper()
+	require.Empty(t, receipt.Logs)
+}
+
+// RequireRevertWith requires err to be a revert with the named error and
+// arguments. Name is foundry.RevertError for `require` messages,
+// foundry.RevertPanic for panics, or the name of an IERC20Errors error.
+func RequireRevertWith(
+	t *testing.T,
+	err error,
+	name string,
+	args ...any,
+) {
+	t.Helper()
+	RequireRevertWithABI(t, nil, err, name, args...)
+}
+
+// RequireRevertWithABI is RequireRevertWith for the custom errors of
+// contractABI.
+func RequireRevertWithABI(
+	t *testing.T,
+	contractABI *abi.ABI,
+	err error,
+	name string,
+	args ...any,
+) {
+	t.Helper()
+	decoder := foundry.NewRevertDecoder()
+	if contractABI != nil {
+		decoder = foundry.NewRevertDecoder(contractABI)
+	}
+
+	revert, decodeErr := decoder.DecodeError(err)
+	require.NoError(t, decodeErr)
+	require.Truef(
+		t,
+		revert.Matches(name, args...),
+		"want revert %s %v, got %s",
+		name,
+		args,
+		revert,
+	)
+}
+
 func StartAnvil(
 	t *testing.T,
 	silent bool,
+	opts ...foundry.AnvilOption,
 ) (*foundry.Anvil, func()) {
 	t.Helper()
-	anvil, err := foundry.NewAnvil(BroadcastDir, ScriptDir)
+	anvil, err := foundry.NewAnvil(BroadcastDir, ScriptDir, opts...)
 	require.NoError(t, err)
 
 	err = anvil.Start(t.Context(), silent)
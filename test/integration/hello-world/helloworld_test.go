@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tahardi/bearchain/contracts/bindings"
+	"github.com/tahardi/bearchain/test/foundry"
 	"github.com/tahardi/bearchain/test/integration"
 )
 
@@ -20,14 +21,32 @@ func TestHelloWorld(t *testing.T) {
 	defer stop()
 
 	owner := anvil.Account(0)
-	contractAddress, err := anvil.DeployContract(t.Context(), ContractName, owner)
+	deployed, err := anvil.DeployContract(t.Context(), ContractName, owner)
 	require.NoError(t, err)
+	require.NotNil(t, deployed.Deployment.Contract(ContractName))
+	require.NotZero(t, deployed.Deployment.GasUsed)
 
 	client, err := ethclient.Dial(anvil.URL())
 	require.NoError(t, err)
 
+	broadcastReceipt, err := deployed.Deployment.Contract(ContractName).Receipt.ToGeth()
+	require.NoError(t, err)
+	chainReceipt, err := client.TransactionReceipt(t.Context(), broadcastReceipt.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, chainReceipt.BlockHash, broadcastReceipt.BlockHash)
+	require.Equal(t, chainReceipt.GasUsed, broadcastReceipt.GasUsed)
+	require.Equal(t, chainReceipt.ContractAddress, broadcastReceipt.ContractAddress)
+
+	report, err := deployed.Broadcast.Verify(
+		t.Context(),
+		client,
+		foundry.WithArtifacts(integration.OutDir),
+	)
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+
 	want := "Hello, World!"
-	hwContract, err := bindings.NewHelloWorld(*contractAddress, client)
+	hwContract, err := bindings.NewHelloWorld(*deployed.Address, client)
 	require.NoError(t, err)
 
 	// when
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tahardi/bearchain/test/foundry"
+)
+
+// SharedAnvil is a single Anvil started once per test package, typically
+// from TestMain, whose fixtures are deployed once and whose chain state is
+// snapshotted before and reverted after each test.
+//
+// Shared tests are serial by design: they share one chain, so Isolate runs
+// them one at a time, trading the parallelism of one Anvil per test for a
+// single deployment of the fixtures. Tests that need to run in parallel start
+// their own Anvil with StartAnvil instead.
+type SharedAnvil struct {
+	anvil *foundry.Anvil
+	mu    sync.Mutex
+}
+
+// Fixture deploys or seeds the chain state every test of a package starts
+// from.
+type Fixture func(ctx context.Context, anvil *foundry.Anvil) error
+
+// StartSharedAnvil starts an Anvil and applies the fixtures in order.
+//
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//	    shared, err := integration.StartSharedAnvil(ctx, true, deployBearCoin)
+//	    ...
+//	    code := m.Run()
+//	    _ = shared.Stop()
+//	    os.Exit(code)
+//	}
+func StartSharedAnvil(
+	ctx context.Context,
+	silent bool,
+	fixtures ...Fixture,
+) (*SharedAnvil, error) {
+	anvil, err := foundry.NewAnvil(BroadcastDir, ScriptDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = anvil.Start(ctx, silent)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fixture := range fixtures {
+		err = fixture(ctx, anvil)
+		if err != nil {
+			_ = anvil.Stop()
+			return nil, fmt.Errorf("applying fixture: %w", err)
+		}
+	}
+	return &SharedAnvil{anvil: anvil}, nil
+}
+
+func (s *SharedAnvil) Anvil() *foundry.Anvil { return s.anvil }
+func (s *SharedAnvil) Stop() error           { return s.anvil.Stop() }
+
+// Isolate gives t exclusive use of the shared Anvil until t finishes, then
+// reverts the chain state so every test sees the state left by the fixtures.
+//
+// Isolate holds a lock from the call until t and its subtests finish, so
+// tests calling it must not call t.Parallel, and a subtest of t that calls
+// Isolate again deadlocks.
+//
+// Example:
+//
+//	t.Run("happy path", func(t *testing.T) {
+//	    anvil := shared.Isolate(t)
+//	    ...
+//	})
+func (s *SharedAnvil) Isolate(t *testing.T) *foundry.Anvil {
+	t.Helper()
+	s.mu.Lock()
+	t.Cleanup(s.mu.Unlock)
+
+	id, err := s.anvil.Snapshot(t.Context())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, s.anvil.Revert(context.Background(), id)) })
+	return s.anvil
+}